@@ -4,21 +4,37 @@ import (
 	"fmt"
 	"gengine/context"
 	"gengine/internal/base"
+	"gengine/internal/core"
 	"gengine/internal/core/errors"
 	parser "gengine/internal/iantlr/alr"
 	"gengine/internal/iparser"
-	"gengine/internal/tool"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
-	"sort"
 	"strings"
 	"sync"
 )
 
+// what to do when integer arithmetic in rules overflows, for example int64 + int64 or uint64 - uint64
+const (
+	OVERFLOW_WRAP     = core.OverflowWrap     // golang behaviour, silently wrap around
+	OVERFLOW_ERROR    = core.OverflowError    // return an error with the line and column of the code
+	OVERFLOW_SATURATE = core.OverflowSaturate // clamp to the min or max value of the result type
+)
+
+/**
+the decimal of the decimal mode, a function or method whose parameter is interface{} receives it as it is,
+use its String, Float64 or Int64 to read it
+*/
+type Decimal = core.Decimal
+
 type RuleBuilder struct {
 	Kc *base.KnowledgeContext
 	Dc *context.DataContext
 
 	buildLock sync.Mutex
+
+	decimalMode  bool
+	decimalRules map[string]bool
+	overflow     int
 }
 
 func NewRuleBuilder(dc *context.DataContext) *RuleBuilder {
@@ -29,8 +45,49 @@ func NewRuleBuilder(dc *context.DataContext) *RuleBuilder {
 	}
 }
 
+/**
+decimal mode: numeric literals and arithmetic in rules use an arbitrary-precision decimal instead of int64/float64,
+so 0.1 + 0.2 == 0.3; when a decimal is assigned to a int/uint/float field, it will be converted automatically
+(the fraction is truncated when converted to int, use decimal.Round/RoundBank/Floor/Ceil/Truncate to round it explicitly),
+a decimal out of the range of the field is an error; the parameters of interface{} type receive a Decimal
+
+it takes effect on the rules built after this call
+*/
+func (builder *RuleBuilder) SetDecimalMode(enable bool) {
+	builder.buildLock.Lock()
+	defer builder.buildLock.Unlock()
+	builder.decimalMode = enable
+}
+
+// set the decimal mode of the specified rule, it overrides the mode set by SetDecimalMode
+func (builder *RuleBuilder) SetRuleDecimalMode(ruleName string, enable bool) {
+	builder.buildLock.Lock()
+	defer builder.buildLock.Unlock()
+	if builder.decimalRules == nil {
+		builder.decimalRules = make(map[string]bool)
+	}
+	builder.decimalRules[ruleName] = enable
+}
+
+/**
+set what to do when integer arithmetic overflows: OVERFLOW_WRAP(default), OVERFLOW_ERROR or OVERFLOW_SATURATE
+dividing by zero is always an error
+
+it takes effect on the rules built after this call
+*/
+func (builder *RuleBuilder) SetOverflowMode(mode int) error {
+	if mode != OVERFLOW_WRAP && mode != OVERFLOW_ERROR && mode != OVERFLOW_SATURATE {
+		return errors.New(fmt.Sprintf("overflow mode must be OVERFLOW_WRAP(0) or OVERFLOW_ERROR(1) or OVERFLOW_SATURATE(2), now it is %d", mode))
+	}
+	builder.buildLock.Lock()
+	defer builder.buildLock.Unlock()
+	builder.overflow = mode
+	return nil
+}
+
 //chinese comment :全量更新
 // if update success, all old rules will be delete and you inject new rules will be in the gengine
+// the rules with the same salience are executed by their order in ruleString
 func (builder *RuleBuilder) BuildRuleFromString(ruleString string) error {
 	builder.buildLock.Lock()
 	defer builder.buildLock.Unlock()
@@ -46,6 +103,9 @@ func (builder *RuleBuilder) BuildRuleFromString(ruleString string) error {
 	lexer := parser.NewgengineLexer(in)
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	listener := iparser.NewGengineParserListener(kc)
+	listener.DecimalMode = builder.decimalMode
+	listener.DecimalRules = builder.decimalRules
+	listener.Overflow = builder.overflow
 
 	psr := parser.NewgengineParser(stream)
 	psr.BuildParseTrees = true
@@ -55,11 +115,15 @@ func (builder *RuleBuilder) BuildRuleFromString(ruleString string) error {
 	antlr.ParseTreeWalkerDefault.Walk(listener, psr.Primary())
 
 	if len(errListener.GrammarErrors) > 0 {
-		return errors.New(fmt.Sprintf("%+v", errListener.GrammarErrors))
+		return &errors.MultiError{Errors: errListener.Errors}
 	}
 
 	if len(listener.ParseErrors) > 0 {
-		return errors.New(fmt.Sprintf("%+v", listener.ParseErrors))
+		return &errors.MultiError{Errors: listener.Errors}
+	}
+
+	if e := base.CheckDependencies(kc.RuleEntities); e != nil {
+		return e
 	}
 
 	//initial
@@ -68,18 +132,7 @@ func (builder *RuleBuilder) BuildRuleFromString(ruleString string) error {
 	}
 
 	//sort
-	for _, v := range kc.RuleEntities {
-		kc.SortRules = append(kc.SortRules, v)
-	}
-	if len(kc.SortRules) > 1 {
-		sort.SliceStable(kc.SortRules, func(i, j int) bool {
-			return kc.SortRules[i].Salience > kc.SortRules[j].Salience
-		})
-	}
-
-	for k, v := range kc.SortRules {
-		kc.SortRulesIndexMap[v.RuleName] = k
-	}
+	kc.Sort()
 
 	builder.Kc = kc
 	return nil
@@ -88,6 +141,7 @@ func (builder *RuleBuilder) BuildRuleFromString(ruleString string) error {
 //chinese comment:增量更新
 // if a rule already exists, this method will use the new rule to replace the old one
 // if a rule doesn't exist, this method will add the new rule to the existed rules list
+// among the rules with the same salience, a replaced rule keeps its place and the added rules are after the existed ones
 // in detail: copy from old -> update the copy -> use the updated copy to replace old
 func (builder *RuleBuilder) BuildRuleWithIncremental(ruleString string) error {
 	//make sure incremental update is thread safety!
@@ -105,6 +159,9 @@ func (builder *RuleBuilder) BuildRuleWithIncremental(ruleString string) error {
 
 	kc := base.NewKnowledgeContext()
 	listener := iparser.NewGengineParserListener(kc)
+	listener.DecimalMode = builder.decimalMode
+	listener.DecimalRules = builder.decimalRules
+	listener.Overflow = builder.overflow
 
 	psr := parser.NewgengineParser(stream)
 	psr.BuildParseTrees = true
@@ -114,90 +171,31 @@ func (builder *RuleBuilder) BuildRuleWithIncremental(ruleString string) error {
 	antlr.ParseTreeWalkerDefault.Walk(listener, psr.Primary())
 
 	if len(errListener.GrammarErrors) > 0 {
-		return errors.New(fmt.Sprintf("%+v", errListener.GrammarErrors))
+		return &errors.MultiError{Errors: errListener.Errors}
 	}
 
 	if len(listener.ParseErrors) > 0 {
-		return errors.New(fmt.Sprintf("%+v", listener.ParseErrors))
+		return &errors.MultiError{Errors: listener.Errors}
 	}
 
 	if len(kc.RuleEntities) == 0 {
 		return errors.New(fmt.Sprintf("no rules need to update or add."))
 	}
 
-	//copy
-	newRuleEntities := make(map[string]*base.RuleEntity, len(builder.Kc.RuleEntities))
-	for mk, mv := range builder.Kc.RuleEntities {
-		newRuleEntities[mk] = mv
+	if e := base.CheckIncrementalDependencies(builder.Kc, kc); e != nil {
+		return e
 	}
 
-	//copy
-	newSortRules := make([]*base.RuleEntity, len(builder.Kc.SortRules))
-	for sk, sv := range builder.Kc.SortRules {
-		newSortRules[sk] = sv
-	}
-
-	//kc store the new rules
-	for k, v := range kc.RuleEntities {
-		//init
+	//init
+	for _, v := range kc.RuleEntities {
 		v.Initialize(builder.Dc)
-
-		if vm, ok := newRuleEntities[k]; ok {
-			//repalce update
-			//search
-			index := builder.Kc.SortRulesIndexMap[v.RuleName]
-			if v.Salience == vm.Salience {
-				//replace
-				newSortRules[index] = v
-			} else {
-				newSortRules := append(newSortRules[:index], newSortRules[index+1:]...)
-				//search location to insert
-				low, mid := tool.BinarySearch(newSortRules, v.Salience)
-
-				ire := []*base.RuleEntity{v}
-				if mid == 0 {
-					newRe := append(ire, newSortRules[low:]...)
-					newSortRules = append(newSortRules[:low], newRe...)
-				} else {
-					newRe := append(ire, newSortRules[mid:]...)
-					newSortRules = append(newSortRules[:mid], newRe...)
-				}
-
-				//update the sort index
-				indexMap := make(map[string]int)
-				for k, v := range newSortRules {
-					indexMap[v.RuleName] = k
-				}
-				builder.Kc.SortRulesIndexMap = indexMap
-			}
-
-			newRuleEntities[k] = v
-		} else {
-			//add update
-			low, mid := tool.BinarySearch(newSortRules, v.Salience)
-
-			ire := []*base.RuleEntity{v}
-			if mid == 0 {
-				newRe := append(ire, newSortRules[low:]...)
-				newSortRules = append(newSortRules[:low], newRe...)
-			} else {
-				newRe := append(ire, newSortRules[mid:]...)
-				newSortRules = append(newSortRules[:mid], newRe...)
-			}
-
-			//update the sort index
-			indexMap := make(map[string]int)
-			for k, v := range newSortRules {
-				indexMap[v.RuleName] = k
-			}
-			builder.Kc.SortRulesIndexMap = indexMap
-
-			newRuleEntities[k] = v
-		}
 	}
 
-	builder.Kc.RuleEntities = newRuleEntities
-	builder.Kc.SortRules = newSortRules
+	//merge into a copy, then use the copy to replace old
+	merged := builder.Kc.Merge(kc)
+	builder.Kc.RuleEntities = merged.RuleEntities
+	builder.Kc.SortRules = merged.SortRules
+	builder.Kc.SortRulesIndexMap = merged.SortRulesIndexMap
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"context"
 	"fmt"
 	"gengine/internal/core"
 	"gengine/internal/core/errors"
@@ -8,12 +9,99 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 )
 
 type DataContext struct {
 	lockVars sync.Mutex
 	lockBase sync.Mutex
 	base     map[string]interface{}
+	mutating map[string]bool
+}
+
+/**
+the state of one execution, the engine creates it before the rules run and gives it to every rule in its variables,
+see NewVars, so the executions on the same data context do not share it,
+it is replaced instead of being changed, so the statements read it without lock
+*/
+type Execution struct {
+	Clock   func() time.Time  // the clock used by the builtin time.Now() in rules, nil means the system clock
+	Ctx     context.Context   // the statements of rules stop running when it is done
+	Budget  *core.Budget      // nil means no limit
+	Workers *core.Workers     // the goroutines of the conc blocks, nil means one goroutine per statement
+	Trace   *core.Trace       // nil means no tracing
+	Watcher func(name string) // called with the name of the changed variable or object, nil means no watcher
+	Changes *core.ChangeSet   // nil means no dry run
+	Journal *core.Journal     // nil means it is not transactional
+}
+
+// the state of the rules evaluated without an execution
+var noExecution = &Execution{}
+
+// the variable of the execution in the variables of a rule, it is not an identifier, so no rule can use it
+const executionVar = "@execution"
+
+// the variables of one rule evaluated in the execution, nil execution means no limit, no trace and the system clock
+func NewVars(exec *Execution) map[string]interface{} {
+	Vars := make(map[string]interface{})
+	if exec != nil {
+		Vars[executionVar] = exec
+	}
+	return Vars
+}
+
+// the execution of the variables of a rule, see NewVars, it must not be changed
+func (dc *DataContext) Execution(Vars map[string]interface{}) *Execution {
+	dc.lockVars.Lock()
+	exec, ok := Vars[executionVar].(*Execution)
+	dc.lockVars.Unlock()
+	if ok {
+		return exec
+	}
+	return noExecution
+}
+
+// the error of the context, nil when it is not done
+func (e *Execution) ContextErr() error {
+	if e.Ctx == nil {
+		return nil
+	}
+	return e.Ctx.Err()
+}
+
+// count one evaluated statement against the budget
+func (e *Execution) CountStatement() *errors.BudgetError {
+	if e.Budget == nil {
+		return nil
+	}
+	return e.Budget.Statement()
+}
+
+// count one function or method call against the budget
+func (e *Execution) CountCall() *errors.BudgetError {
+	if e.Budget == nil {
+		return nil
+	}
+	return e.Budget.Call()
+}
+
+// the trace of the running rule, nil when the execution is not traced, it takes no lock then
+func (e *Execution) RuleTrace(ruleName string) *core.RuleTrace {
+	if e.Trace == nil {
+		return nil
+	}
+	return e.Trace.Rule(ruleName)
+}
+
+// whether the execution is transactional
+func (e *Execution) InTransaction() bool {
+	return e.Journal != nil
+}
+
+func (e *Execution) notify(name string) {
+	if e.Watcher != nil {
+		e.Watcher(name)
+	}
 }
 
 func NewDataContext() *DataContext {
@@ -24,9 +112,71 @@ func NewDataContext() *DataContext {
 	return dc
 }
 
+/**
+the builtin namespaces, they can be overridden by adding another object with the same name
+*/
 func (dc *DataContext) loadInnerUDF() {
 	strconv := &define.StrconvWrapper{}
 	dc.Add("strconv", strconv)
+
+	decimal := &define.DecimalWrapper{}
+	dc.Add("decimal", decimal)
+
+	//the clock of the execution replaces the system clock, see ExecMethodWithVars
+	t := define.NewTimeWrapper(nil)
+	dc.Add("time", t)
+
+	str := &define.StringsWrapper{}
+	dc.Add("strings", str)
+
+	m := &define.MathWrapper{}
+	dc.Add("math", m)
+
+	conv := &define.ConvWrapper{}
+	dc.Add("conv", conv)
+}
+
+/**
+mark the methods such as "Order.Cancel" and the functions such as "Notify" which change the injected objects
+or have other side effects, in dry run they are not called, and they return the zero values of their results
+
+the methods not marked are always called, so they should not change anything,
+in forward chaining a successful call of a marked method changes its object, the calls of the others change nothing
+*/
+func (dc *DataContext) MarkMutating(names ...string) {
+	dc.lockBase.Lock()
+	defer dc.lockBase.Unlock()
+	if dc.mutating == nil {
+		dc.mutating = make(map[string]bool)
+	}
+	for _, name := range names {
+		dc.mutating[name] = true
+	}
+}
+
+func (dc *DataContext) isMutating(name string) bool {
+	dc.lockBase.Lock()
+	defer dc.lockBase.Unlock()
+	return dc.mutating[name]
+}
+
+// in dry run, whether the call of the method or function should be recorded instead of being made
+func (dc *DataContext) skipCall(exec *Execution, name string) *core.ChangeSet {
+	if exec.Changes != nil && dc.isMutating(name) {
+		return exec.Changes
+	}
+	return nil
+}
+
+// whether the variable, such as "Order.Total" or "Total", is an injected object or a field of it
+func (dc *DataContext) injected(variable string) bool {
+	if i := strings.Index(variable, "."); i >= 0 {
+		variable = variable[:i]
+	}
+	dc.lockBase.Lock()
+	defer dc.lockBase.Unlock()
+	_, ok := dc.base[variable]
+	return ok
 }
 
 func (dc *DataContext) Add(key string, obj interface{}) {
@@ -62,12 +212,25 @@ execute the injected functions
 function execute supply multi return values, but simplify ,just return one value
 */
 func (dc *DataContext) ExecFunc(funcName string, parameters []interface{}) (interface{}, error) {
+	return dc.ExecFuncWithVars(nil, funcName, parameters)
+}
+
+// the same as ExecFunc, but in the execution of the variables of the rule, see NewVars
+func (dc *DataContext) ExecFuncWithVars(Vars map[string]interface{}, funcName string, parameters []interface{}) (interface{}, error) {
 	dc.lockBase.Lock()
 	v := dc.base[funcName]
 	dc.lockBase.Unlock()
 
 	if v != nil {
-		params := core.ParamsTypeChange(v, parameters)
+		if changes := dc.skipCall(dc.Execution(Vars), funcName); changes != nil {
+			changes.Call(funcName, parameters)
+			return core.GetRawTypeValue(core.ZeroResults(reflect.ValueOf(v)))
+		}
+
+		params, e := core.ParamsTypeChange(v, parameters)
+		if e != nil {
+			return nil, e
+		}
 		fun := reflect.ValueOf(v)
 		args := make([]reflect.Value, 0)
 		for _, param := range params {
@@ -89,6 +252,11 @@ execute the struct's functions
 function execute supply multi return values, but simplify ,just return one value
 */
 func (dc *DataContext) ExecMethod(methodName string, args []interface{}) (interface{}, error) {
+	return dc.ExecMethodWithVars(nil, methodName, args)
+}
+
+// the same as ExecMethod, but in the execution of the variables of the rule, see NewVars
+func (dc *DataContext) ExecMethodWithVars(Vars map[string]interface{}, methodName string, args []interface{}) (interface{}, error) {
 	structAndMethod := strings.Split(methodName, ".")
 	//Dimit rule
 	if len(structAndMethod) != 2 {
@@ -100,10 +268,27 @@ func (dc *DataContext) ExecMethod(methodName string, args []interface{}) (interf
 	dc.lockBase.Unlock()
 
 	if v != nil {
+		exec := dc.Execution(Vars)
+		if _, ok := v.(*define.TimeWrapper); ok && exec.Clock != nil {
+			v = define.NewTimeWrapper(exec.Clock)
+		}
+		if changes := dc.skipCall(exec, methodName); changes != nil {
+			f := reflect.ValueOf(v).MethodByName(structAndMethod[1])
+			if !f.IsValid() {
+				return nil, errors.New(fmt.Sprintf("NOT FOUND Function: %s", structAndMethod[1]))
+			}
+			changes.Call(methodName, args)
+			return core.GetRawTypeValue(core.ZeroResults(f))
+		}
+
 		res, err := core.InvokeFunction(v, structAndMethod[1], args)
 		if err != nil {
 			return nil, err
 		}
+		//only the mutating methods change the object, the getters and the failed calls don't
+		if dc.isMutating(methodName) {
+			exec.notify(structAndMethod[0])
+		}
 		return res, nil
 	}
 	return nil, errors.New(fmt.Sprintf("Not found method: %s", methodName))
@@ -126,6 +311,11 @@ func (dc *DataContext) GetValue(Vars map[string]interface{}, variable string) (i
 		dc.lockBase.Unlock()
 
 		if v != nil {
+			if changes := dc.Execution(Vars).Changes; changes != nil {
+				if nv, ok := changes.Get(variable); ok {
+					return nv, nil
+				}
+			}
 			return core.GetStructAttributeValue(v, structAndField[1])
 		}
 
@@ -143,6 +333,11 @@ func (dc *DataContext) GetValue(Vars map[string]interface{}, variable string) (i
 		dc.lockBase.Unlock()
 
 		if v != nil {
+			if changes := dc.Execution(Vars).Changes; changes != nil {
+				if nv, ok := changes.Get(variable); ok {
+					return nv, nil
+				}
+			}
 			return v, nil
 		}
 		//in RuleEntity
@@ -154,7 +349,94 @@ func (dc *DataContext) GetValue(Vars map[string]interface{}, variable string) (i
 	return nil, errors.New(fmt.Sprintf("Did not found variable : %s ", variable))
 }
 
+/**
+set the variable, such as "Order.Total", "Total" or a variable of the rule, to newValue,
+in dry run the writes to the injected objects are recorded in the change set of the execution, see NewVars
+*/
 func (dc *DataContext) SetValue(Vars map[string]interface{}, variable string, newValue interface{}) error {
+	var err error
+	exec := dc.Execution(Vars)
+	if exec.Changes != nil && dc.injected(variable) {
+		err = dc.recordValue(exec.Changes, Vars, variable, newValue)
+	} else if exec.Journal != nil {
+		undo := dc.undoValue(variable)
+		err = dc.setValue(Vars, variable, newValue)
+		if err == nil && undo != nil {
+			exec.Journal.Record(undo)
+		}
+	} else {
+		err = dc.setValue(Vars, variable, newValue)
+	}
+	if err == nil {
+		exec.notify(variable)
+	}
+	return err
+}
+
+// the function which restores the injected variable to its current value, nil when it is not injected or can not be set
+func (dc *DataContext) undoValue(variable string) func() {
+	target := dc.settable(variable)
+	if !target.IsValid() {
+		return nil
+	}
+	old := reflect.New(target.Type()).Elem()
+	old.Set(target)
+	return func() {
+		target.Set(old)
+	}
+}
+
+/**
+the settable value of the injected variable, such as "Order.Total" or "Total",
+it is invalid when the variable is not injected or can not be set
+*/
+func (dc *DataContext) settable(variable string) reflect.Value {
+	structAndField := strings.Split(variable, ".")
+	if len(structAndField) > 2 {
+		return reflect.Value{}
+	}
+
+	dc.lockBase.Lock()
+	v, ok := dc.base[structAndField[0]]
+	dc.lockBase.Unlock()
+	if !ok {
+		return reflect.Value{}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return reflect.Value{}
+	}
+	rv = rv.Elem()
+	if len(structAndField) == 2 {
+		if rv.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		rv = rv.FieldByName(structAndField[1])
+	}
+	if !rv.IsValid() || !rv.CanSet() {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+// record the write to the injected variable instead of making it
+func (dc *DataContext) recordValue(changes *core.ChangeSet, Vars map[string]interface{}, variable string, newValue interface{}) error {
+	old, err := dc.GetValue(Vars, variable)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(variable, ".") {
+		//the injected single value is a pointer
+		if rv := reflect.ValueOf(old); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			old = rv.Elem().Interface()
+		}
+	}
+	changes.Set(variable, old, newValue)
+	return nil
+}
+
+func (dc *DataContext) setValue(Vars map[string]interface{}, variable string, newValue interface{}) error {
 	if strings.Contains(variable, ".") {
 		structAndField := strings.Split(variable, ".")
 		//Dimit rule
@@ -196,6 +478,13 @@ func (dc *DataContext) setSingleValue(obj interface{}, fieldName string, value i
 		}
 
 		objKind := reflect.ValueOf(obj).Elem().Kind()
+		if d, ok := value.(core.Decimal); ok {
+			v, e := core.DecimalToKind(d, objKind)
+			if e != nil {
+				return e
+			}
+			value = v
+		}
 		valueKind := reflect.ValueOf(value).Kind()
 		if objKind == valueKind {
 			reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(value))
@@ -253,7 +542,157 @@ func (dc *DataContext) setSingleValue(obj interface{}, fieldName string, value i
 	}
 }
 
+/**
+set the element of the map, slice or array to newValue,
+in dry run the writes to the injected ones are recorded in the change set of the execution, see NewVars
+*/
 func (dc *DataContext) SetMapVarValue(Vars map[string]interface{}, mapVarName, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64, newValue interface{}) error {
+	var err error
+	exec := dc.Execution(Vars)
+	if exec.Changes != nil && dc.injected(mapVarName) {
+		err = dc.recordMapVarValue(exec.Changes, Vars, mapVarName, mapVarStrkey, mapVarVarkey, mapVarIntkey, newValue)
+	} else if exec.Journal != nil {
+		undo := dc.undoMapVarValue(Vars, mapVarName, mapVarStrkey, mapVarVarkey, mapVarIntkey)
+		err = dc.setMapVarValue(Vars, mapVarName, mapVarStrkey, mapVarVarkey, mapVarIntkey, newValue)
+		if err == nil && undo != nil {
+			exec.Journal.Record(undo)
+		}
+	} else {
+		err = dc.setMapVarValue(Vars, mapVarName, mapVarStrkey, mapVarVarkey, mapVarIntkey, newValue)
+	}
+	if err == nil {
+		exec.notify(mapVarName)
+	}
+	return err
+}
+
+// the key of the element of a map, slice or array
+func (dc *DataContext) mapVarKey(Vars map[string]interface{}, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64) (interface{}, error) {
+	if len(mapVarVarkey) > 0 {
+		return dc.GetValue(Vars, mapVarVarkey)
+	}
+	if len(mapVarStrkey) > 0 {
+		return mapVarStrkey, nil
+	}
+	return mapVarIntkey, nil
+}
+
+// in dry run, the intended value of the element of the injected map, slice or array, ok is false when nothing is written to it
+func (dc *DataContext) GetMapVarChange(Vars map[string]interface{}, mapVarName, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64) (interface{}, bool) {
+	changes := dc.Execution(Vars).Changes
+	if changes == nil || !dc.injected(mapVarName) {
+		return nil, false
+	}
+	key, err := dc.mapVarKey(Vars, mapVarStrkey, mapVarVarkey, mapVarIntkey)
+	if err != nil {
+		return nil, false
+	}
+	return changes.Get(core.MapVarPath(mapVarName, key))
+}
+
+// record the write to the element of the injected map, slice or array instead of making it
+func (dc *DataContext) recordMapVarValue(changes *core.ChangeSet, Vars map[string]interface{}, mapVarName, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64, newValue interface{}) error {
+	value, err := dc.GetValue(Vars, mapVarName)
+	if err != nil {
+		return err
+	}
+	key, err := dc.mapVarKey(Vars, mapVarStrkey, mapVarVarkey, mapVarIntkey)
+	if err != nil {
+		return err
+	}
+
+	path := core.MapVarPath(mapVarName, key)
+	old, ok := changes.Get(path)
+	if !ok {
+		old = elementValue(value, key)
+	}
+	changes.Set(path, old, newValue)
+	return nil
+}
+
+/**
+the function which restores the element of the injected map, slice or array to its current value,
+the element which does not exist in a map is deleted, nil when it is not injected or can not be set
+*/
+func (dc *DataContext) undoMapVarValue(Vars map[string]interface{}, mapVarName, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64) (undo func()) {
+	defer func() {
+		if e := recover(); e != nil {
+			undo = nil
+		}
+	}()
+
+	container := dc.settable(mapVarName)
+	if !container.IsValid() {
+		return nil
+	}
+	//the map or slice in a pointer
+	if container.Kind() == reflect.Ptr {
+		container = container.Elem()
+	}
+	key, err := dc.mapVarKey(Vars, mapVarStrkey, mapVarVarkey, mapVarIntkey)
+	if err != nil {
+		return nil
+	}
+
+	switch container.Kind() {
+	case reflect.Map:
+		wantedKey, e := core.GetWantedValue(key, container.Type().Key().String())
+		if e != nil {
+			return nil
+		}
+		k := reflect.ValueOf(wantedKey)
+		element := container.MapIndex(k)
+		if !element.IsValid() {
+			return func() {
+				container.SetMapIndex(k, reflect.Value{})
+			}
+		}
+		old := reflect.New(element.Type()).Elem()
+		old.Set(element)
+		return func() {
+			container.SetMapIndex(k, old)
+		}
+	case reflect.Slice, reflect.Array:
+		element := container.Index(int(reflect.ValueOf(key).Int()))
+		if !element.CanSet() {
+			return nil
+		}
+		old := reflect.New(element.Type()).Elem()
+		old.Set(element)
+		return func() {
+			element.Set(old)
+		}
+	}
+	return nil
+}
+
+// the element of the map, slice or array, nil when it does not exist
+func elementValue(value interface{}, key interface{}) (element interface{}) {
+	defer func() {
+		if e := recover(); e != nil {
+			element = nil
+		}
+	}()
+
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Map:
+		wantedKey, e := core.GetWantedValue(key, rv.Type().Key().String())
+		if e != nil {
+			return nil
+		}
+		ev := rv.MapIndex(reflect.ValueOf(wantedKey))
+		if !ev.IsValid() {
+			return nil
+		}
+		return ev.Interface()
+	case reflect.Slice, reflect.Array:
+		return rv.Index(int(reflect.ValueOf(key).Int())).Interface()
+	}
+	return nil
+}
+
+func (dc *DataContext) setMapVarValue(Vars map[string]interface{}, mapVarName, mapVarStrkey, mapVarVarkey string, mapVarIntkey int64, newValue interface{}) error {
 
 	//value is map or slice or array
 	value, e := dc.GetValue(Vars, mapVarName)
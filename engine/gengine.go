@@ -1,23 +1,408 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"gengine/builder"
+	gcontext "gengine/context"
 	"gengine/internal/base"
+	"gengine/internal/core"
 	"gengine/internal/core/errors"
-	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/martian/v3/log"
 )
 
+// the limits of one execution, see Gengine.SetLimits
+type Limits = core.Limits
+
+// the error returned when an execution exceeds one of its limits, it has the rule name and the source position
+type BudgetError = errors.BudgetError
+
+// every BudgetError is ErrBudgetExceeded, use errors.Is(err, ErrBudgetExceeded) to check it
+var ErrBudgetExceeded = errors.ErrBudgetExceeded
+
+// the error of one rule with its position, kind and cause, use errors.As(err, &ruleErr) to get it
+type RuleError = errors.RuleError
+
+/**
+every Execute method returns the errors of the rules as a *MultiError,
+errors.Is and errors.As work on every one of them
+*/
+type MultiError = errors.MultiError
+
+// the kinds of RuleError
+const (
+	KindParse     = errors.KindParse
+	KindType      = errors.KindType
+	KindCall      = errors.KindCall
+	KindBudget    = errors.KindBudget
+	KindCancelled = errors.KindCancelled
+	KindPanic     = errors.KindPanic
+	KindRollback  = errors.KindRollback
+	KindSelect    = errors.KindSelect
+)
+
+// the cause of the error of a rule which executes rollback, see Gengine.SetTransactional
+var ErrRollback = errors.ErrRollback
+
+// a recovered panic with its stack, it is the cause of the RuleError of a panic
+type PanicError = errors.PanicError
+
+// the trace of one execution, it can be serialized to json, see Gengine.SetTracing
+type Trace = core.Trace
+
+type RuleTrace = core.RuleTrace
+
+type ConditionTrace = core.ConditionTrace
+
+type OperandTrace = core.OperandTrace
+
+type AssignmentTrace = core.AssignmentTrace
+
+// the writes intended by a dry run execution, see Gengine.SetDryRun
+type ChangeSet = core.ChangeSet
+
+type Change = core.Change
+
+// the default max number of rule firings of one forward chaining execution, see Gengine.SetMaxCycles
+const DefaultMaxCycles = 1000
+
 type Gengine struct {
+	clock       func() time.Time
+	limits      Limits
+	maxCycles   int64
+	tracing     bool
+	tracer      func(ctx context.Context, trace *Trace)
+	reporting   bool
+	reporter    func(ctx context.Context, report *Report)
+	listeners   []Listener
+	workerLock  sync.Mutex    // it guards parallelism and workers, they are read by the running executions
+	parallelism int           // the max number of goroutines of a concurrent step, zero means one goroutine per rule
+	workers     *core.Workers // the goroutines of the concurrent steps, they are reused by the executions
+	failFast    bool
+	dryRun      bool
+	dryRunner   func(ctx context.Context, changes *ChangeSet)
+	transaction bool
+
+	lastLock sync.Mutex // it guards the results of the last execution, they are kept only when the caller asks for them
+	trace    *Trace
+	report   *Report
+	changes  *ChangeSet
 }
 
 func NewGengine() *Gengine {
 	return &Gengine{}
 }
 
+/**
+set the clock used by the builtin time.Now() in rules, nil means the system clock
+a fixed clock makes rules depending on the current time reproducible, for tests and replays
+*/
+func (g *Gengine) SetClock(clock func() time.Time) {
+	g.clock = clock
+}
+
+/**
+set the limits of every execution, zero means no limit:
+MaxStatements limits the evaluated statements, MaxCalls limits the function and method calls,
+MaxDuration limits the wall time
+
+when a limit is exceeded, the execution stops with a *BudgetError
+*/
+func (g *Gengine) SetLimits(limits Limits) {
+	g.limits = limits
+}
+
+/**
+set the max number of rule firings of one forward chaining execution, zero means DefaultMaxCycles,
+it stops the rules which keep activating each other, when it is exceeded, the execution stops with a *BudgetError
+*/
+func (g *Gengine) SetMaxCycles(n int64) {
+	g.maxCycles = n
+}
+
+/**
+enable or disable tracing, when it is enabled, every execution records which rules ran,
+the conditions of when, if and else if with their operand values, the assignments and the errors,
+the rules whose when conditions are false are recorded as skipped,
+and the engine keeps the trace of the last finished execution, see GetTrace and SetTraceHandler
+*/
+func (g *Gengine) SetTracing(enable bool) {
+	g.tracing = enable
+	if !enable {
+		g.lastLock.Lock()
+		g.trace = nil
+		g.lastLock.Unlock()
+	}
+}
+
+/**
+the trace of the last finished execution, nil when tracing is disabled,
+when the engine runs many executions at the same time, use SetTraceHandler to get the trace of every one
+*/
+func (g *Gengine) GetTrace() *Trace {
+	g.lastLock.Lock()
+	defer g.lastLock.Unlock()
+	return g.trace
+}
+
+/**
+trace every execution, handler is called with the trace of every execution before the execute method returns,
+ctx is the one given to the execute method, nil handler stops calling it, see SetTracing
+*/
+func (g *Gengine) SetTraceHandler(handler func(ctx context.Context, trace *Trace)) {
+	g.tracer = handler
+}
+
+/**
+enable or disable reporting, when it is enabled, every execution reports the status, the duration and the error
+of every rule it was given, and the engine keeps the report of the last finished execution,
+see GetReport, SetReportHandler and RuleStatus
+*/
+func (g *Gengine) SetReporting(enable bool) {
+	g.reporting = enable
+	if !enable {
+		g.lastLock.Lock()
+		g.report = nil
+		g.lastLock.Unlock()
+	}
+}
+
+/**
+the report of the last finished execution, nil when reporting is disabled,
+when the engine runs many executions at the same time, use SetReportHandler to get the report of every one
+*/
+func (g *Gengine) GetReport() *Report {
+	g.lastLock.Lock()
+	defer g.lastLock.Unlock()
+	return g.report
+}
+
+/**
+report every execution, handler is called with the report of every execution before the execute method returns,
+ctx is the one given to the execute method, nil handler stops calling it, see SetReporting
+*/
+func (g *Gengine) SetReportHandler(handler func(ctx context.Context, report *Report)) {
+	g.reporter = handler
+}
+
+/**
+set the max number of goroutines which execute the rules of one concurrent step, zero means one goroutine per rule,
+it is used by the concurrent, mix, inverse mix and salience layered models and the selected rules of them,
+and by the conc blocks in rules, every goroutine executes the rules one after another until all of them are taken
+
+the goroutines are kept by the engine and reused by its executions, they exit after they are idle for a while,
+the goroutine which calls the execute method is counted in n, and the conc blocks share the same goroutines,
+so one execution has at most n goroutines
+
+when n is changed, the goroutines kept for the old n are stopped, the running executions finish their steps
+with the goroutines which are busy and their own ones
+*/
+func (g *Gengine) SetMaxParallelism(n int) {
+	g.workerLock.Lock()
+	if g.workers != nil && g.parallelism == n {
+		g.workerLock.Unlock()
+		return
+	}
+	old := g.workers
+	g.workers = core.NewWorkers(n)
+	g.parallelism = n
+	g.workerLock.Unlock()
+	old.Close()
+}
+
+// the max parallelism and the goroutines of the next execution
+func (g *Gengine) getWorkers() *core.Workers {
+	g.workerLock.Lock()
+	defer g.workerLock.Unlock()
+	return g.workers
+}
+
+/**
+enable or disable fail fast in the concurrent steps of the concurrent, mix, inverse mix, salience layered and DAG models:
+once a rule of a step returns an error or halts, no more rule of the step is started,
+and the running rules are cancelled at their next statement
+
+the step returns a *FailFastError with the first error and the cancelled rules, use errors.As(err, &failFastErr) to get it,
+when a rule halts, nil is returned, and the cancelled rules are stopped in the report
+*/
+func (g *Gengine) SetFailFast(enable bool) {
+	g.failFast = enable
+}
+
+/**
+enable or disable dry run, when it is enabled, the writes of the rules to the injected objects,
+the fields, the elements of the maps, slices and arrays, and the injected single values, are not made,
+they are recorded in a change set with their paths, old and new values,
+and the engine keeps the change set of the last finished execution, see GetChangeSet and SetDryRunHandler
+
+the rules read the values they have written, so they behave as if the writes were made,
+the calls of the methods and functions marked by DataContext.MarkMutating are recorded instead of being made,
+the other calls are made, and they see the injected objects as they are
+*/
+func (g *Gengine) SetDryRun(enable bool) {
+	g.dryRun = enable
+	if !enable {
+		g.lastLock.Lock()
+		g.changes = nil
+		g.lastLock.Unlock()
+	}
+}
+
+/**
+the change set of the last finished execution, nil when dry run is disabled,
+when the engine runs many executions at the same time, use SetDryRunHandler to get the change set of every one
+*/
+func (g *Gengine) GetChangeSet() *ChangeSet {
+	g.lastLock.Lock()
+	defer g.lastLock.Unlock()
+	return g.changes
+}
+
+/**
+dry run every execution, handler is called with the change set of every execution before the execute method returns,
+ctx is the one given to the execute method, nil handler stops calling it, see SetDryRun
+*/
+func (g *Gengine) SetDryRunHandler(handler func(ctx context.Context, changes *ChangeSet)) {
+	g.dryRunner = handler
+}
+
+/**
+enable or disable the transactional mode, when it is enabled, every execution journals the writes of the rules
+to the injected objects, the fields, the elements of the maps, slices and arrays, and the injected single values,
+and restores the values before them when the execution returns an error, so a failed execution leaves nothing half done
+
+a rule can execute the rollback statement to roll back the execution, the rules after it are not executed,
+and the error of the rule is a *RuleError of KindRollback, use errors.Is(err, ErrRollback) to check it
+
+the calls of methods and functions can not be rolled back
+*/
+func (g *Gengine) SetTransactional(enable bool) {
+	g.transaction = enable
+}
+
+// register a listener, listeners are called in the order they are added, see Listener
+func (g *Gengine) AddListener(l Listener) {
+	g.listeners = append(g.listeners, l)
+}
+
+// the state of one execution
+type execution struct {
+	engine    *Gengine
+	ctx       context.Context
+	trace     *Trace
+	listeners []Listener
+	report    *Report
+	changes   *ChangeSet // the writes intended by the rules in dry run
+	rules     []*base.RuleEntity // the rules given to the execution, the report lists them
+	workers   *core.Workers
+	failFast  bool
+	state     *gcontext.Execution // given to the rules, see gcontext.NewVars
+	journal   *core.Journal // the writes of the rules in the transactional mode
+	halted    int32         // set by the halt and rollback statements, no more rule is executed
+}
+
+/**
+prepare the state of one execution, it is given to the rules instead of being kept in the data context,
+so the rule builder can be executed by many goroutines at the same time
+*/
+func (g *Gengine) setup(ctx context.Context, rb *builder.RuleBuilder) *execution {
+	var trace *Trace
+	if g.tracing || g.tracer != nil {
+		trace = core.NewTrace()
+	}
+
+	var changes *ChangeSet
+	if g.dryRun || g.dryRunner != nil {
+		changes = core.NewChangeSet()
+	}
+
+	var journal *core.Journal
+	if g.transaction {
+		journal = core.NewJournal()
+	}
+
+	workers := g.getWorkers()
+	// the state is created once, the statements read it without lock
+	state := &gcontext.Execution{Clock: g.clock, Ctx: ctx, Workers: workers, Trace: trace, Changes: changes, Journal: journal}
+	if !g.limits.IsZero() {
+		state.Budget = core.NewBudget(g.limits)
+	}
+
+	var report *Report
+	if g.reporting || g.reporter != nil {
+		report = newReport()
+	}
+
+	ex := &execution{engine: g, ctx: ctx, trace: trace, listeners: g.listeners, report: report, changes: changes, rules: rb.Kc.SortRules, workers: workers, failFast: g.failFast, state: state, journal: journal}
+	for _, l := range ex.listeners {
+		l.BeforeExecute(ctx)
+	}
+	return ex
+}
+
+// the error of the execution is returned as a *MultiError, in the transactional mode the writes are rolled back on it
+func (ex *execution) finish(err error) error {
+	if err != nil {
+		if _, ok := err.(*MultiError); !ok {
+			err = &MultiError{Errors: []error{err}}
+		}
+		if ex.journal != nil {
+			ex.journal.Rollback()
+		}
+	}
+	if ex.trace != nil {
+		ex.trace.Finish()
+	}
+	if ex.report != nil {
+		ex.report.finish(ex.rules, err)
+	}
+	for _, l := range ex.listeners {
+		l.AfterExecute(ex.ctx, err)
+	}
+	ex.engine.publish(ex)
+	return err
+}
+
+// give the results of the execution to the handlers, and keep them for the getters when they are enabled
+func (g *Gengine) publish(ex *execution) {
+	if ex.trace != nil {
+		if g.tracer != nil {
+			g.tracer(ex.ctx, ex.trace)
+		}
+		if g.tracing {
+			g.lastLock.Lock()
+			g.trace = ex.trace
+			g.lastLock.Unlock()
+		}
+	}
+	if ex.report != nil {
+		if g.reporter != nil {
+			g.reporter(ex.ctx, ex.report)
+		}
+		if g.reporting {
+			g.lastLock.Lock()
+			g.report = ex.report
+			g.lastLock.Unlock()
+		}
+	}
+	if ex.changes != nil {
+		if g.dryRunner != nil {
+			g.dryRunner(ex.ctx, ex.changes)
+		}
+		if g.dryRun {
+			g.lastLock.Lock()
+			g.changes = ex.changes
+			g.lastLock.Unlock()
+		}
+	}
+}
+
+// set StopTag to true in a rule to stop the rules after it in the methods with stop tag, the halt statement does the same in every method
 type Stag struct {
 	StopTag bool
 }
@@ -28,24 +413,38 @@ sort execute model
 when b is true it means when there are many rules， if one rule execute error，continue to execute rules after the occur error rule
 */
 func (g *Gengine) Execute(rb *builder.RuleBuilder, b bool) error {
+	return g.ExecuteWithContext(context.Background(), rb, b)
+}
+
+/**
+the same as Execute, but it stops when ctx is done, and returns ctx.Err() wrapped with the rule name
+
+the rules after ctx is done will not be executed, and the running rule is aborted between statements
+*/
+func (g *Gengine) ExecuteWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
-	var eMsg []string
+	var errs []error
 	for _, r := range rb.Kc.SortRules {
-		err := r.Execute()
+		err := ex.executeRule(r)
 		if err != nil {
+			if isStopped(err) {
+				return err
+			}
 			if b {
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, err))
+				errs = append(errs, err)
 			} else {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, err))
+				return err
 			}
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -62,18 +461,28 @@ it used in this scene:
 where some high priority rules execute finished, you don't want to execute to the last rules, you can use sTag to control it out of gengine
 */
 func (g *Gengine) ExecuteWithStopTagDirect(rb *builder.RuleBuilder, b bool, sTag *Stag) error {
+	return g.ExecuteWithStopTagDirectWithContext(context.Background(), rb, b, sTag)
+}
+
+// the same as ExecuteWithStopTagDirect, but it stops when ctx is done
+func (g *Gengine) ExecuteWithStopTagDirectWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, sTag *Stag) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
-	var eMsg []string
+	var errs []error
 	for _, r := range rb.Kc.SortRules {
-		err := r.Execute()
+		err := ex.executeRule(r)
 		if err != nil {
+			if isStopped(err) {
+				return err
+			}
 			if b {
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, err))
+				errs = append(errs, err)
 			} else {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, err))
+				return err
 			}
 		}
 
@@ -82,8 +491,8 @@ func (g *Gengine) ExecuteWithStopTagDirect(rb *builder.RuleBuilder, b bool, sTag
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 
 	return nil
@@ -94,31 +503,24 @@ func (g *Gengine) ExecuteWithStopTagDirect(rb *builder.RuleBuilder, b bool, sTag
  in this mode, it will not consider the priority  and not consider err control
 */
 func (g *Gengine) ExecuteConcurrent(rb *builder.RuleBuilder) error {
+	return g.ExecuteConcurrentWithContext(context.Background(), rb)
+}
+
+// the same as ExecuteConcurrent, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteConcurrentWithContext(ctx context.Context, rb *builder.RuleBuilder) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.RuleEntities) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
-	var wg sync.WaitGroup
-	wg.Add(len(rb.Kc.RuleEntities))
-	for _, r := range rb.Kc.RuleEntities {
-		rr := r
-		go func() {
-			e := rr.Execute()
-			if e != nil {
-				errLock.Lock()
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-				errLock.Unlock()
-			}
-			wg.Done()
-		}()
+	errs, stopped := ex.executeConcurrent(rb.Kc.SortRules)
+	if stopped != nil {
+		return stopped
 	}
-	wg.Wait()
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -130,39 +532,34 @@ func (g *Gengine) ExecuteConcurrent(rb *builder.RuleBuilder) error {
  first to execute the most high priority rule，then concurrently to execute last rules without consider the priority
 */
 func (g *Gengine) ExecuteMixModel(rb *builder.RuleBuilder) error {
+	return g.ExecuteMixModelWithContext(context.Background(), rb)
+}
+
+// the same as ExecuteMixModel, but it stops when ctx is done
+func (g *Gengine) ExecuteMixModelWithContext(ctx context.Context, rb *builder.RuleBuilder) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	rules := rb.Kc.SortRules
-	e := rules[0].Execute()
+	e := ex.executeRule(rules[0])
 	if e != nil {
-		return errors.New(fmt.Sprintf("the most high priority rule: \"%s\"  executed, error:\n %+v", rules[0].RuleName, e))
+		return e
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
+	var errs []error
 	if (len(rules) - 1) >= 1 {
-		var wg sync.WaitGroup
-		wg.Add(len(rules) - 1)
-		for _, r := range rules[1:] {
-			rr := r
-			go func() {
-				e := rr.Execute()
-				if e != nil {
-					errLock.Lock()
-					eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-					errLock.Unlock()
-				}
-				wg.Done()
-			}()
+		var stopped error
+		errs, stopped = ex.executeConcurrent(rules[1:])
+		if stopped != nil {
+			return stopped
 		}
-		wg.Wait()
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -181,41 +578,36 @@ where the first rule execute finished, you don't want to execute to the last rul
 
 */
 func (g *Gengine) ExecuteMixModelWithStopTagDirect(rb *builder.RuleBuilder, sTag *Stag) error {
+	return g.ExecuteMixModelWithStopTagDirectWithContext(context.Background(), rb, sTag)
+}
+
+// the same as ExecuteMixModelWithStopTagDirect, but it stops when ctx is done
+func (g *Gengine) ExecuteMixModelWithStopTagDirectWithContext(ctx context.Context, rb *builder.RuleBuilder, sTag *Stag) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	rules := rb.Kc.SortRules
-	e := rules[0].Execute()
+	e := ex.executeRule(rules[0])
 	if e != nil {
-		return errors.New(fmt.Sprintf("the most high priority rule: \"%s\"  executed, error:\n %+v", rules[0].RuleName, e))
+		return e
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
+	var errs []error
 	if !sTag.StopTag {
 		if (len(rules) - 1) >= 1 {
-			var wg sync.WaitGroup
-			wg.Add(len(rules) - 1)
-			for _, r := range rules[1:] {
-				rr := r
-				go func() {
-					e := rr.Execute()
-					if e != nil {
-						errLock.Lock()
-						eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-						errLock.Unlock()
-					}
-					wg.Done()
-				}()
+			var stopped error
+			errs, stopped = ex.executeConcurrent(rules[1:])
+			if stopped != nil {
+				return stopped
 			}
-			wg.Wait()
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -224,8 +616,15 @@ func (g *Gengine) ExecuteMixModelWithStopTagDirect(rb *builder.RuleBuilder, sTag
 user can choose specified name rules to run with sort, and it will continue to execute the last rules,even if there rule execute error
 */
 func (g *Gengine) ExecuteSelectedRules(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRules, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.RuleEntities) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	var rules []*base.RuleEntity
@@ -237,28 +636,30 @@ func (g *Gengine) ExecuteSelectedRules(rb *builder.RuleBuilder, names []string)
 			log.Errorf("no such rule named: \"%s\"", name)
 		}
 	}
+	ex.rules = rules
 
 	if len(rules) < 1 {
-		return errors.New(fmt.Sprintf("no rules have been selected, names=%+v", names))
+		return selectError(fmt.Sprintf("no rules have been selected, names=%+v", names))
 	}
 
 	if len(rules) >= 2 {
-		sort.SliceStable(rules, func(i, j int) bool {
-			return rules[i].Salience > rules[j].Salience
-		})
+		base.SortRules(rules)
 	}
 
-	var eMsg []string
+	var errs []error
 	for _, rule := range rules {
 		rr := rule
-		e := rr.Execute()
+		e := ex.executeRule(rr)
 		if e != nil {
-			eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
+			if isStopped(e) {
+				return e
+			}
+			errs = append(errs, e)
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -268,8 +669,15 @@ user can choose specified name rules to run with sort
 b bool:control whether continue to execute last rules ,when a rule execute error; if b == true ,the func is same to ExecuteSelectedRules
 */
 func (g *Gengine) ExecuteSelectedRulesWithControl(rb *builder.RuleBuilder, b bool, names []string) error {
+	return g.ExecuteSelectedRulesWithControlWithContext(context.Background(), rb, b, names)
+}
+
+// the same as ExecuteSelectedRulesWithControl, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesWithControlWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, names []string) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	var rules []*base.RuleEntity
@@ -281,32 +689,34 @@ func (g *Gengine) ExecuteSelectedRulesWithControl(rb *builder.RuleBuilder, b boo
 			log.Errorf("no such rule named: \"%s\"", name)
 		}
 	}
+	ex.rules = rules
 
 	if len(rules) < 1 {
-		return errors.New(fmt.Sprintf("no rule has been selected, names=%+v", names))
+		return selectError(fmt.Sprintf("no rule has been selected, names=%+v", names))
 	}
 
 	if len(rules) >= 2 {
-		sort.SliceStable(rules, func(i, j int) bool {
-			return rules[i].Salience > rules[j].Salience
-		})
+		base.SortRules(rules)
 	}
 
-	var eMsg []string
+	var errs []error
 	for _, rule := range rules {
 		rr := rule
-		e := rr.Execute()
+		e := ex.executeRule(rr)
 		if e != nil {
+			if isStopped(e) {
+				return e
+			}
 			if b {
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
+				errs = append(errs, e)
 			} else {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
+				return e
 			}
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -316,8 +726,15 @@ user can choose specified name rules to run with sort
 b bool:control whether continue to execute last rules ,when a rule execute error; if b == true ,the func is same to ExecuteSelectedRules
 */
 func (g *Gengine) ExecuteSelectedRulesWithControlAndStopTag(rb *builder.RuleBuilder, b bool, sTag *Stag, names []string) error {
+	return g.ExecuteSelectedRulesWithControlAndStopTagWithContext(context.Background(), rb, b, sTag, names)
+}
+
+// the same as ExecuteSelectedRulesWithControlAndStopTag, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesWithControlAndStopTagWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, sTag *Stag, names []string) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.SortRules) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	var rules []*base.RuleEntity
@@ -329,26 +746,28 @@ func (g *Gengine) ExecuteSelectedRulesWithControlAndStopTag(rb *builder.RuleBuil
 			log.Errorf("no such rule named: \"%s\"", name)
 		}
 	}
+	ex.rules = rules
 
 	if len(rules) < 1 {
-		return errors.New(fmt.Sprintf("no rule has been selected, names=%+v", names))
+		return selectError(fmt.Sprintf("no rule has been selected, names=%+v", names))
 	}
 
 	if len(rules) >= 2 {
-		sort.SliceStable(rules, func(i, j int) bool {
-			return rules[i].Salience > rules[j].Salience
-		})
+		base.SortRules(rules)
 	}
 
-	var eMsg []string
+	var errs []error
 	for _, rule := range rules {
 		rr := rule
-		e := rr.Execute()
+		e := ex.executeRule(rr)
 		if e != nil {
+			if isStopped(e) {
+				return e
+			}
 			if b {
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
+				errs = append(errs, e)
 			} else {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
+				return e
 			}
 		}
 
@@ -357,8 +776,8 @@ func (g *Gengine) ExecuteSelectedRulesWithControlAndStopTag(rb *builder.RuleBuil
 		}
 	}
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -367,8 +786,15 @@ func (g *Gengine) ExecuteSelectedRulesWithControlAndStopTag(rb *builder.RuleBuil
 user can choose specified name rules to concurrent run
 */
 func (g *Gengine) ExecuteSelectedRulesConcurrent(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesConcurrentWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesConcurrent, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteSelectedRulesConcurrentWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.RuleEntities) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	var rules []*base.RuleEntity
@@ -380,41 +806,28 @@ func (g *Gengine) ExecuteSelectedRulesConcurrent(rb *builder.RuleBuilder, names
 			log.Errorf("no such rule named: \"%s\"", name)
 		}
 	}
+	ex.rules = rules
 
 	if len(rules) < 1 {
-		return errors.New(fmt.Sprintf("no rule has been selected, names=%+v", names))
+		return selectError(fmt.Sprintf("no rule has been selected, names=%+v", names))
 	}
 
 	if len(rules) <= 1 {
-		e := rules[0].Execute()
+		e := ex.executeRule(rules[0])
 		if e != nil {
-			return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rules[0].RuleName, e))
+			return e
 		}
 		return nil
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
 	// len(rule) >= 2
-	var wg sync.WaitGroup
-	wg.Add(len(rules))
-	for _, r := range rules {
-		rr := r
-		go func() {
-			e := rr.Execute()
-			if e != nil {
-				errLock.Lock()
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-				errLock.Unlock()
-			}
-			wg.Done()
-		}()
+	errs, stopped := ex.executeConcurrent(rules)
+	if stopped != nil {
+		return stopped
 	}
-	wg.Wait()
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
@@ -423,8 +836,15 @@ func (g *Gengine) ExecuteSelectedRulesConcurrent(rb *builder.RuleBuilder, names
 user can choose specified name rules to run with mix model
 */
 func (g *Gengine) ExecuteSelectedRulesMixModel(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesMixModelWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesMixModel, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesMixModelWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if len(rb.Kc.RuleEntities) == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
 	var rules []*base.RuleEntity
@@ -436,113 +856,426 @@ func (g *Gengine) ExecuteSelectedRulesMixModel(rb *builder.RuleBuilder, names []
 			log.Errorf("no such rule named: \"%s\"", name)
 		}
 	}
+	ex.rules = rules
 
 	rLen := len(rules)
 	if rLen < 1 {
-		return errors.New(fmt.Sprintf("no rule has been selected, names=%+v", names))
+		return selectError(fmt.Sprintf("no rule has been selected, names=%+v", names))
 	}
 
 	if rLen == 1 {
-		e := rules[0].Execute()
+		e := ex.executeRule(rules[0])
 		if e != nil {
-			return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rules[0].RuleName, e))
+			return e
 		}
 		return nil
 	}
 
-	sort.SliceStable(rules, func(i, j int) bool {
-		return rules[i].Salience > rules[j].Salience
-	})
+	base.SortRules(rules)
 
 	if rLen == 2 {
 		for _, r := range rules {
-			err := r.Execute()
+			err := ex.executeRule(r)
 			if err != nil {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, err))
+				return err
 			}
 		}
 		return nil
 	}
 
 	// rLen >= 3
-	e := rules[0].Execute()
+	e := ex.executeRule(rules[0])
 	if e != nil {
-		return errors.New(fmt.Sprintf("the most high priority rule: \"%s\"  executed, error:\n %+v", rules[0].RuleName, e))
+		return e
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
-	var wg sync.WaitGroup
-	wg.Add(rLen - 1)
-	for _, r := range rules[1:] {
-		rr := r
-		go func() {
-			e := rr.Execute()
-			if e != nil {
-				errLock.Lock()
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-				errLock.Unlock()
-			}
-			wg.Done()
-		}()
+	errs, stopped := ex.executeConcurrent(rules[1:])
+	if stopped != nil {
+		return stopped
 	}
-	wg.Wait()
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
 	return nil
 }
 
 //inverse mix model
 func (g *Gengine) ExecuteInverseMixModel(rb *builder.RuleBuilder) error {
+	return g.ExecuteInverseMixModelWithContext(context.Background(), rb)
+}
+
+// the same as ExecuteInverseMixModel, but it stops when ctx is done
+func (g *Gengine) ExecuteInverseMixModelWithContext(ctx context.Context, rb *builder.RuleBuilder) (err error) {
 	rules := rb.Kc.SortRules
 	length := len(rules)
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
 	if length == 0 {
-		return errors.New("no rule has been injected into engine.")
+		return selectError("no rule has been injected into engine.")
 	}
 
-	if length <= 2 {
-		for _, r := range rules {
-			e := r.Execute()
+	return ex.executeInverseMix(rules)
+}
+
+//inverse mix model with user selected
+func (g *Gengine) ExecuteSelectedRulesInverseMixModel(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesInverseMixModelWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesInverseMixModel, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesInverseMixModelWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
+	var rules []*base.RuleEntity
+	//choose user need!
+	for _, name := range names {
+		if re, ok := rb.Kc.RuleEntities[name]; ok {
+			rules = append(rules, re)
+		} else {
+			log.Errorf("no such rule named: \"%s\"", name)
+		}
+	}
+
+	length := len(rules)
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = rules
+
+	if length == 0 {
+		return selectError("no rule has been selected to execute.")
+	}
+
+	//resort
+	base.SortRules(rules)
+
+	return ex.executeInverseMix(rules)
+}
+
+/**
+forward chaining model
+
+the rules are kept in an agenda: the rule whose when condition is true and has the highest priority fires first,
+after a rule fires, the rules whose when conditions read the facts it changed are evaluated again,
+and they fire again if their conditions are still true; the execution ends when no rule can fire
+
+the facts changed by a rule are the variables and map vars it assigns, and the objects whose methods marked by
+DataContext.MarkMutating it calls successfully;
+a rule with no-loop is not activated by its own changes, a rule without when condition fires once
+
+it stops at the first error, and when the number of firings exceeds the max cycles, see SetMaxCycles
+*/
+func (g *Gengine) ExecuteForwardChaining(rb *builder.RuleBuilder) error {
+	return g.ExecuteForwardChainingWithContext(context.Background(), rb)
+}
+
+// the same as ExecuteForwardChaining, but it stops when ctx is done
+func (g *Gengine) ExecuteForwardChainingWithContext(ctx context.Context, rb *builder.RuleBuilder) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	if len(rb.Kc.SortRules) == 0 {
+		return selectError("no rule has been injected into engine.")
+	}
+
+	return ex.executeForwardChaining(rb.Kc.SortRules, g.getMaxCycles())
+}
+
+//forward chaining model with user selected
+func (g *Gengine) ExecuteSelectedRulesForwardChaining(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesForwardChainingWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesForwardChaining, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesForwardChainingWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
+	var rules []*base.RuleEntity
+	for _, name := range names {
+		if re, ok := rb.Kc.RuleEntities[name]; ok {
+			rules = append(rules, re)
+		} else {
+			log.Errorf("no such rule named: \"%s\"", name)
+		}
+	}
+
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = rules
+
+	if len(rules) == 0 {
+		return selectError("no rule has been selected to execute.")
+	}
+
+	base.SortRules(rules)
+
+	return ex.executeForwardChaining(rules, g.getMaxCycles())
+}
+
+func (g *Gengine) getMaxCycles() int64 {
+	if g.maxCycles <= 0 {
+		return DefaultMaxCycles
+	}
+	return g.maxCycles
+}
+
+// the facts changed by the firing rule, the rule may change them concurrently in a conc block
+type changedFacts struct {
+	lock  sync.Mutex
+	names []string
+}
+
+func (c *changedFacts) add(name string) {
+	c.lock.Lock()
+	c.names = append(c.names, name)
+	c.lock.Unlock()
+}
+
+// whether one of the changed facts affects one of the read facts
+func (c *changedFacts) affects(reads []string) bool {
+	for _, changed := range c.names {
+		for _, read := range reads {
+			if base.FactAffects(changed, read) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// the rules are sorted by priority
+func (ex *execution) executeForwardChaining(rules []*base.RuleEntity, maxCycles int64) error {
+	reads := make([][]string, len(rules))
+	// whose when condition should be evaluated
+	pending := make([]bool, len(rules))
+	// whose when condition is true and has not fired since it was evaluated
+	active := make([]bool, len(rules))
+	// the traces started by the last evaluations of the when conditions
+	traces := make([]*RuleTrace, len(rules))
+	for i, r := range rules {
+		reads[i] = r.Facts()
+		pending[i] = true
+	}
+
+	var cycles int64
+	for !ex.isHalted() {
+		for i, r := range rules {
+			if !pending[i] {
+				continue
+			}
+			pending[i] = false
+			if e := ex.ctx.Err(); e != nil {
+				e = cancelledError(r.RuleName, e)
+				ex.record(r, RuleStopped, 0, e)
+				return e
+			}
+			rt, ok, e := ex.isActive(r, ex.state)
 			if e != nil {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, e))
+				return e
+			}
+			active[i] = ok
+			traces[i] = rt
+		}
+
+		next := -1
+		for i := range rules {
+			if active[i] {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil
+		}
+
+		r := rules[next]
+		active[next] = false
+		cycles++
+		if cycles > maxCycles {
+			be := &BudgetError{Kind: errors.BudgetCycles, Limit: strconv.FormatInt(maxCycles, 10), RuleName: r.RuleName}
+			if r.When != nil {
+				be.LineNum, be.Column, be.Code = r.When.LineNum, r.When.Column, r.When.Code
+			}
+			e := ex.ruleError(r, be)
+			if traces[next] != nil {
+				traces[next].Finish(e)
+			}
+			ex.record(r, RuleStopped, 0, e)
+			return e
+		}
+		if e := ex.ctx.Err(); e != nil {
+			e = cancelledError(r.RuleName, e)
+			if traces[next] != nil {
+				traces[next].Finish(e)
+			}
+			ex.record(r, RuleStopped, 0, e)
+			return e
+		}
+
+		changed := &changedFacts{}
+		state := *ex.state
+		state.Watcher = changed.add
+		e := ex.fireRule(r, traces[next], &state)
+		if e != nil {
+			return e
+		}
+
+		for i := range rules {
+			if i == next && r.NoLoop {
+				continue
+			}
+			if changed.affects(reads[i]) {
+				pending[i] = true
+				active[i] = false
 			}
 		}
-		return nil
 	}
+	return nil
+}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
-	var wg sync.WaitGroup
-	wg.Add(length - 1)
-	for _, r := range rules[:length-1] {
-		rr := r
-		go func() {
-			e := rr.Execute()
+/**
+salience layered model
+
+the rules with the same salience are in the same layer, the layers are executed one by one from the highest salience,
+and the rules in a layer are executed concurrently, a layer starts after all the rules of the layer before it finish
+
+when b is true, if some rules of a layer execute error, continue to execute the layers after it;
+when b is false, the layers after it are not executed
+*/
+func (g *Gengine) ExecuteSalienceLayered(rb *builder.RuleBuilder, b bool) error {
+	return g.ExecuteSalienceLayeredWithContext(context.Background(), rb, b)
+}
+
+// the same as ExecuteSalienceLayered, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteSalienceLayeredWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	if len(rb.Kc.SortRules) == 0 {
+		return selectError("no rule has been injected into engine.")
+	}
+
+	return ex.executeSalienceLayered(rb.Kc.SortRules, b, nil)
+}
+
+/**
+salience layered model, if stopTag become true after a layer finishes, the layers after it are not executed
+see ExecuteSalienceLayered and ExecuteWithStopTagDirect
+*/
+func (g *Gengine) ExecuteSalienceLayeredWithStopTagDirect(rb *builder.RuleBuilder, b bool, sTag *Stag) error {
+	return g.ExecuteSalienceLayeredWithStopTagDirectWithContext(context.Background(), rb, b, sTag)
+}
+
+// the same as ExecuteSalienceLayeredWithStopTagDirect, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteSalienceLayeredWithStopTagDirectWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, sTag *Stag) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	if len(rb.Kc.SortRules) == 0 {
+		return selectError("no rule has been injected into engine.")
+	}
+
+	return ex.executeSalienceLayered(rb.Kc.SortRules, b, sTag)
+}
+
+//salience layered model with user selected
+func (g *Gengine) ExecuteSelectedRulesSalienceLayered(rb *builder.RuleBuilder, b bool, names []string) error {
+	return g.ExecuteSelectedRulesSalienceLayeredWithContext(context.Background(), rb, b, names)
+}
+
+// the same as ExecuteSelectedRulesSalienceLayered, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteSelectedRulesSalienceLayeredWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, names []string) (err error) {
+	var rules []*base.RuleEntity
+	for _, name := range names {
+		if re, ok := rb.Kc.RuleEntities[name]; ok {
+			rules = append(rules, re)
+		} else {
+			log.Errorf("no such rule named: \"%s\"", name)
+		}
+	}
+
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = rules
+
+	if len(rules) == 0 {
+		return selectError("no rule has been selected to execute.")
+	}
+
+	base.SortRules(rules)
+
+	return ex.executeSalienceLayered(rules, b, nil)
+}
+
+// the rules are sorted by salience, sTag may be nil
+func (ex *execution) executeSalienceLayered(rules []*base.RuleEntity, b bool, sTag *Stag) error {
+	var errs []error
+	for start := 0; start < len(rules); {
+		end := start + 1
+		for end < len(rules) && rules[end].Salience == rules[start].Salience {
+			end++
+		}
+		layer := rules[start:end]
+		start = end
+
+		if len(layer) == 1 {
+			e := ex.executeRule(layer[0])
 			if e != nil {
-				errLock.Lock()
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-				errLock.Unlock()
+				if isStopped(e) {
+					return e
+				}
+				errs = append(errs, e)
+			}
+		} else {
+			layerErrs, stopped := ex.executeConcurrent(layer)
+			if stopped != nil {
+				return stopped
 			}
-			wg.Done()
-		}()
+			errs = append(errs, layerErrs...)
+		}
+
+		if len(errs) > 0 && !b {
+			break
+		}
+		if sTag != nil && sTag.StopTag {
+			break
+		}
 	}
-	wg.Wait()
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
 	}
+	return nil
+}
+
+/**
+DAG model
+
+the rules declare the rules they depend on in their headers by `after "ruleA", "ruleB"`,
+a rule is executed after all the rules it depends on, and the rules which do not depend on each other are executed concurrently:
+the rules are split into layers, and the layers are executed one by one, the rules in a layer are executed concurrently
 
-	return rules[length-1].Execute()
+when some rules of a layer return errors, the next layers are not executed
+*/
+func (g *Gengine) ExecuteDAG(rb *builder.RuleBuilder) error {
+	return g.ExecuteDAGWithContext(context.Background(), rb)
 }
 
-//inverse mix model with user selected
-func (g *Gengine) ExecuteSelectedRulesInverseMixModel(rb *builder.RuleBuilder, names []string) error {
+// the same as ExecuteDAG, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteDAGWithContext(ctx context.Context, rb *builder.RuleBuilder) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	if len(rb.Kc.SortRules) == 0 {
+		return selectError("no rule has been injected into engine.")
+	}
+
+	return ex.executeDAG(rb.Kc.SortRules)
+}
+
+/**
+DAG model with user selected,
+the dependencies on the rules which are not selected are ignored
+*/
+func (g *Gengine) ExecuteSelectedRulesDAG(rb *builder.RuleBuilder, names []string) error {
+	return g.ExecuteSelectedRulesDAGWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesDAG, but no more rule is started when ctx is done
+func (g *Gengine) ExecuteSelectedRulesDAGWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (err error) {
 	var rules []*base.RuleEntity
-	//choose user need!
 	for _, name := range names {
 		if re, ok := rb.Kc.RuleEntities[name]; ok {
 			rules = append(rules, re)
@@ -551,48 +1284,419 @@ func (g *Gengine) ExecuteSelectedRulesInverseMixModel(rb *builder.RuleBuilder, n
 		}
 	}
 
-	length := len(rules)
-	if length == 0 {
-		return errors.New("no rule has been selected to execute.")
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = rules
+
+	if len(rules) == 0 {
+		return selectError("no rule has been selected to execute.")
 	}
 
-	//resort
-	sort.SliceStable(rules, func(i, j int) bool {
-		return rules[i].Salience > rules[j].Salience
-	})
+	base.SortRules(rules)
+
+	return ex.executeDAG(rules)
+}
+
+// execute the layers of the rules one by one
+func (ex *execution) executeDAG(rules []*base.RuleEntity) error {
+	layers, e := base.Layers(rules)
+	if e != nil {
+		return e
+	}
+
+	for _, layer := range layers {
+		if len(layer) == 1 {
+			e := ex.executeRule(layer[0])
+			if e != nil {
+				return e
+			}
+			continue
+		}
+
+		errs, stopped := ex.executeConcurrent(layer)
+		if stopped != nil {
+			return stopped
+		}
+		if len(errs) > 0 {
+			return &MultiError{Errors: errs}
+		}
+	}
+	return nil
+}
+
+/**
+first match model, for the rules which are a decision list
 
+the rules are checked one by one from the highest priority, and only the first rule whose when condition is true is executed,
+a rule without when condition always matches, so it can be the default rule with the lowest priority
+
+it returns the name of the matched rule, "" when no rule matches, it stops at the first error of the when conditions
+*/
+func (g *Gengine) ExecuteFirstMatch(rb *builder.RuleBuilder) (string, error) {
+	return g.ExecuteFirstMatchWithContext(context.Background(), rb)
+}
+
+// the same as ExecuteFirstMatch, but it stops when ctx is done
+func (g *Gengine) ExecuteFirstMatchWithContext(ctx context.Context, rb *builder.RuleBuilder) (matched string, err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	if len(rb.Kc.SortRules) == 0 {
+		return "", selectError("no rule has been injected into engine.")
+	}
+
+	return ex.executeFirstMatch(rb.Kc.SortRules)
+}
+
+//first match model with user selected
+func (g *Gengine) ExecuteSelectedRulesFirstMatch(rb *builder.RuleBuilder, names []string) (string, error) {
+	return g.ExecuteSelectedRulesFirstMatchWithContext(context.Background(), rb, names)
+}
+
+// the same as ExecuteSelectedRulesFirstMatch, but it stops when ctx is done
+func (g *Gengine) ExecuteSelectedRulesFirstMatchWithContext(ctx context.Context, rb *builder.RuleBuilder, names []string) (matched string, err error) {
+	var rules []*base.RuleEntity
+	for _, name := range names {
+		if re, ok := rb.Kc.RuleEntities[name]; ok {
+			rules = append(rules, re)
+		} else {
+			log.Errorf("no such rule named: \"%s\"", name)
+		}
+	}
+
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = rules
+
+	if len(rules) == 0 {
+		return "", selectError("no rule has been selected to execute.")
+	}
+
+	base.SortRules(rules)
+
+	return ex.executeFirstMatch(rules)
+}
+
+// the rules are sorted by priority, the error of the matched rule is returned with its name
+func (ex *execution) executeFirstMatch(rules []*base.RuleEntity) (string, error) {
+	for _, r := range rules {
+		if e := ex.ctx.Err(); e != nil {
+			e = cancelledError(r.RuleName, e)
+			ex.record(r, RuleStopped, 0, e)
+			return "", e
+		}
+
+		rt, active, e := ex.isActive(r, ex.state)
+		if e != nil {
+			return "", e
+		}
+		if !active {
+			continue
+		}
+		return r.RuleName, ex.fireRule(r, rt, ex.state)
+	}
+	return "", nil
+}
+
+// concurrently execute all the rules except the last one, then execute the last one
+func (ex *execution) executeInverseMix(rules []*base.RuleEntity) error {
+	length := len(rules)
 	if length <= 2 {
 		for _, r := range rules {
-			e := r.Execute()
+			e := ex.executeRule(r)
 			if e != nil {
-				return errors.New(fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", r.RuleName, e))
+				return e
 			}
 		}
 		return nil
 	}
 
-	var errLock sync.Mutex
-	var eMsg []string
-
-	var wg sync.WaitGroup
-	wg.Add(length - 1)
-	for _, r := range rules[:length-1] {
-		rr := r
-		go func() {
-			e := rr.Execute()
-			if e != nil {
-				errLock.Lock()
-				eMsg = append(eMsg, fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", rr.RuleName, e))
-				errLock.Unlock()
+	errs, stopped := ex.executeConcurrent(rules[:length-1])
+	if stopped != nil {
+		return stopped
+	}
+
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
+	}
+
+	return ex.executeRule(rules[length-1])
+}
+
+/**
+execute one rule if its when condition is true, when ctx is done or the budget is exceeded,
+the returned error identifies the rule, and the caller should not execute any other rule, see isStopped
+
+after a rule executes halt, no rule is executed, so every model stops at the rules after it
+*/
+func (ex *execution) executeRule(r *base.RuleEntity) error {
+	return ex.executeRuleIn(r, ex.state)
+}
+
+// the same as executeRule, but the rule is executed in the state, such as the one of a step with fail fast
+func (ex *execution) executeRuleIn(r *base.RuleEntity, state *gcontext.Execution) error {
+	if ex.isHalted() {
+		ex.record(r, RuleStopped, 0, nil)
+		return nil
+	}
+	if e := ex.ctx.Err(); e != nil {
+		e = cancelledError(r.RuleName, e)
+		ex.record(r, RuleStopped, 0, e)
+		return e
+	}
+
+	rt, active, e := ex.isActive(r, state)
+	if e != nil || !active {
+		return e
+	}
+	return ex.fireRule(r, rt, state)
+}
+
+/**
+evaluate the when condition of the rule in the state, when tracing, the trace of the rule starts before it,
+and the execution of the rule continues the returned trace; the errors and the inactive rules are recorded
+*/
+func (ex *execution) isActive(r *base.RuleEntity, state *gcontext.Execution) (*RuleTrace, bool, error) {
+	var rt *RuleTrace
+	if ex.trace != nil && r.When != nil {
+		rt = ex.trace.BeginRule(r.RuleName)
+	}
+	active, e := r.IsActive(state)
+	if e != nil {
+		e = ex.ruleError(r, e)
+		if rt != nil {
+			rt.Finish(e)
+		}
+		ex.record(r, RuleErrored, 0, e)
+		return nil, false, e
+	}
+	if !active {
+		if rt != nil {
+			rt.Skip()
+		}
+		ex.record(r, RuleSkipped, 0, nil)
+	}
+	return rt, active, nil
+}
+
+// execute the content of one rule in the state, its when condition has been checked, rt is the trace started by it
+func (ex *execution) fireRule(r *base.RuleEntity, rt *RuleTrace, state *gcontext.Execution) error {
+	for _, l := range ex.listeners {
+		if !l.BeforeRule(ex.ctx, r.RuleName) {
+			if rt != nil {
+				rt.Skip()
 			}
-			wg.Done()
-		}()
+			ex.record(r, RuleSkipped, 0, nil)
+			return nil
+		}
 	}
-	wg.Wait()
 
-	if len(eMsg) > 0 {
-		return errors.New(fmt.Sprintf("%+v", eMsg))
+	if rt == nil && ex.trace != nil {
+		rt = ex.trace.BeginRule(r.RuleName)
+	}
+	start := time.Now()
+	e := r.Execute(state)
+	if e == base.ErrHalt {
+		atomic.StoreInt32(&ex.halted, 1)
+		e = nil
+	}
+	if errors.Is(e, ErrRollback) {
+		atomic.StoreInt32(&ex.halted, 1)
+	}
+	e = ex.ruleError(r, e)
+	duration := time.Since(start)
+	if rt != nil {
+		rt.Finish(e)
 	}
+	for _, l := range ex.listeners {
+		l.AfterRule(ex.ctx, r.RuleName, e, duration)
+	}
+	if e == nil {
+		ex.record(r, RuleExecuted, duration, nil)
+	} else if isStopped(e) {
+		ex.record(r, RuleStopped, duration, e)
+	} else {
+		ex.record(r, RuleErrored, duration, e)
+	}
+	return e
+}
 
-	return rules[length-1].Execute()
+// record an outcome of the rule when reporting is enabled
+func (ex *execution) record(r *base.RuleEntity, status RuleStatus, duration time.Duration, err error) {
+	if ex.report != nil {
+		ex.report.record(r.RuleName, status, duration, err)
+	}
+}
+
+/**
+the same as executeRule, but a panic is returned as the error of the rule, so it does not crash the process,
+it is used in the goroutines of the engine, the panics of the statements have been returned with their positions
+*/
+func (ex *execution) executeRuleSafe(r *base.RuleEntity, state *gcontext.Execution) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = &RuleError{RuleName: r.RuleName, Kind: errors.KindPanic, Cause: errors.NewPanicError(e)}
+			ex.record(r, RuleErrored, 0, err)
+		}
+	}()
+	return ex.executeRuleIn(r, state)
+}
+
+func (ex *execution) isHalted() bool {
+	return atomic.LoadInt32(&ex.halted) == 1
+}
+
+/**
+the errors of the rule are returned as *RuleError with the rule name,
+the errors which stop the execution are classified by their causes
+*/
+func (ex *execution) ruleError(r *base.RuleEntity, e error) error {
+	if e == nil {
+		return nil
+	}
+
+	var be *BudgetError
+	if errors.As(e, &be) {
+		be.RuleName = r.RuleName
+		return &RuleError{RuleName: r.RuleName, LineNum: be.LineNum, Column: be.Column, Code: be.Code, Kind: errors.KindBudget, Cause: be}
+	}
+	if ex.ctx.Err() != nil {
+		return cancelledError(r.RuleName, ex.ctx.Err())
+	}
+	// the rule is cancelled by fail fast, see executeConcurrentFailFast
+	if errors.Is(e, context.Canceled) {
+		return cancelledError(r.RuleName, e)
+	}
+	if re, ok := e.(*RuleError); ok {
+		re.RuleName = r.RuleName
+		return re
+	}
+	return &RuleError{RuleName: r.RuleName, Kind: errors.KindType, Cause: e}
+}
+
+// the error of a concurrent step with fail fast, see SetFailFast
+type FailFastError = errors.FailFastError
+
+// errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) works on it
+func cancelledError(ruleName string, err error) error {
+	return &RuleError{RuleName: ruleName, Kind: errors.KindCancelled, Cause: err}
+}
+
+// whether the error stops the whole execution
+func isStopped(e error) bool {
+	return errors.Is(e, context.Canceled) || errors.Is(e, context.DeadlineExceeded) || errors.Is(e, ErrBudgetExceeded)
+}
+
+// the error of an execution without rule to execute, it is returned through ex.finish as the other errors
+func selectError(msg string) error {
+	return &RuleError{Kind: errors.KindSelect, Cause: errors.New(msg)}
+}
+
+/**
+execute rules concurrently with at most the max parallelism goroutines, see SetMaxParallelism,
+no more rule is started once ctx is done or the budget is exceeded
+stopped is the first error which stops the execution, see isStopped
+*/
+func (ex *execution) executeConcurrent(rules []*base.RuleEntity) (errs []error, stopped error) {
+	if ex.failFast {
+		return nil, ex.executeConcurrentFailFast(rules)
+	}
+
+	var errLock sync.Mutex
+
+	ex.workers.Run(len(rules), func(i int) bool {
+		errLock.Lock()
+		s := stopped
+		errLock.Unlock()
+		if s != nil {
+			return false
+		}
+
+		e := ex.executeRuleSafe(rules[i], ex.state)
+		if e != nil {
+			errLock.Lock()
+			defer errLock.Unlock()
+			if isStopped(e) {
+				if stopped == nil {
+					stopped = e
+				}
+				return false
+			}
+			errs = append(errs, e)
+		}
+		return true
+	})
+
+	return errs, stopped
+}
+
+/**
+execute rules concurrently with fail fast, see SetFailFast, the rules are executed in a copy of the state
+with the context of this step, which is checked between the statements, so cancelling it only cancels the rules of this step
+*/
+func (ex *execution) executeConcurrentFailFast(rules []*base.RuleEntity) error {
+	ctx, cancel := context.WithCancel(ex.ctx)
+	defer cancel()
+	state := *ex.state
+	state.Ctx = ctx
+
+	var lock sync.Mutex
+	var first, stopped error
+	// whether the rule is aborted or not started
+	cancelled := make([]bool, len(rules))
+	for i := range cancelled {
+		cancelled[i] = true
+	}
+
+	ex.workers.Run(len(rules), func(i int) bool {
+		if e := ex.ctx.Err(); e != nil {
+			lock.Lock()
+			if stopped == nil {
+				stopped = cancelledError(rules[i].RuleName, e)
+			}
+			lock.Unlock()
+			return false
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		e := ex.executeRuleSafe(rules[i], &state)
+		lock.Lock()
+		defer lock.Unlock()
+		if e != nil && isStopped(e) {
+			if ctx.Err() != nil && ex.ctx.Err() == nil && !errors.Is(e, ErrBudgetExceeded) {
+				return false
+			}
+			if stopped == nil {
+				stopped = e
+			}
+			cancelled[i] = false
+			cancel()
+			return false
+		}
+
+		cancelled[i] = false
+		if e != nil && first == nil {
+			first = e
+		}
+		if e != nil || ex.isHalted() {
+			cancel()
+			return false
+		}
+		return true
+	})
+
+	if stopped != nil {
+		return stopped
+	}
+	if first != nil {
+		var names []string
+		for i, c := range cancelled {
+			if c {
+				names = append(names, rules[i].RuleName)
+			}
+		}
+		return &FailFastError{Err: first, Cancelled: names}
+	}
+	return nil
 }
@@ -1,31 +1,48 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"gengine/builder"
-	"gengine/context"
+	gcontext "gengine/context"
 	"gengine/internal/base"
 	"gengine/internal/core/errors"
 	parser "gengine/internal/iantlr/alr"
 	"gengine/internal/iparser"
-	"gengine/internal/tool"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"sync"
+	"time"
 
 	"github.com/google/martian/v3/log"
 )
 
 const (
-	SORT_MODEL        = 1
-	CONCOURRENT_MODEL = 2
-	MIX_MODEL         = 3
-	INVERSE_MIX_MODEL = 4
+	SORT_MODEL             = 1
+	CONCOURRENT_MODEL      = 2
+	MIX_MODEL              = 3
+	INVERSE_MIX_MODEL      = 4
+	FORWARD_CHAINING_MODEL = 5
+	DAG_MODEL              = 6
+	SALIENCE_LAYERED_MODEL = 7
+	FIRST_MATCH_MODEL      = 8
 )
 
+func checkExecModel(em int) error {
+	if em != SORT_MODEL && em != CONCOURRENT_MODEL && em != MIX_MODEL && em != INVERSE_MIX_MODEL && em != FORWARD_CHAINING_MODEL && em != DAG_MODEL && em != SALIENCE_LAYERED_MODEL && em != FIRST_MATCH_MODEL {
+		return errors.New(fmt.Sprintf("exec model must be SORT_MODEL(1) or CONCOURRENT_MODEL(2) or MIX_MODEL(3) or INVERSE_MIX_MODEL(4) or FORWARD_CHAINING_MODEL(5) or DAG_MODEL(6) or SALIENCE_LAYERED_MODEL(7) or FIRST_MATCH_MODEL(8), now it is %d", em))
+	}
+	return nil
+}
+
+// returned when no engine of the pool is free in the max wait or before ctx is done, use errors.Is(err, ErrPoolExhausted) to check it
+var ErrPoolExhausted = errors.New("gengine pool exhausted, no engine is free")
+
 // when you use NewGenginePool, you just think of it as the connection pool of mysql, the higher QPS you want to support, the more resource you need to give
 type GenginePool struct {
-	runningLock  sync.Mutex
+	freeLock     sync.Mutex //it guards freeGengines and additionGengines
 	freeGengines []*gengineWrapper
+	inUse        chan struct{} //one token per engine in use, taking a token blocks when all the engines are in use
+	stats        *poolStats
 
 	//just for check whether a rule exist
 	ruleBuilder *builder.RuleBuilder
@@ -33,24 +50,38 @@ type GenginePool struct {
 	execModel int
 	apis      map[string]interface{}
 
-	additionLock     sync.Mutex
 	additionGengines []*gengineWrapper
 	additionNum      int64
 
-	updateLock sync.Mutex
-	clear      bool //whether rules has been cleared ，if true it means there is no rules in gengine
+	updateLock sync.Mutex //it serializes the updates
+	//it guards ruleBuilder, rbSlice, clear and execModel, the updates build the new rules first, then publish them with it
+	rulesLock sync.RWMutex
+	clear     bool //whether rules has been cleared ，if true it means there is no rules in gengine
 
 	rbSlice []*builder.RuleBuilder
 	//total gengine instance number
 	max int64
 
-	getEngineLock sync.RWMutex //just one can get this lock
+	execLock    sync.RWMutex
+	clock       func() time.Time
+	limits      Limits
+	maxCycles   int64
+	parallelism int
+	failFast    bool
+	transaction bool
+	maxWait     time.Duration
+	tracer      func(ctx context.Context, trace *Trace)
+	reporter    func(ctx context.Context, report *Report)
+	dryRunner   func(ctx context.Context, changes *ChangeSet)
+	matcher     func(ctx context.Context, ruleName string)
+	listeners   []Listener
 }
 
 type gengineWrapper struct {
 	tag         int64 // one to one between the ruleBuilder slice
 	rulebuilder *builder.RuleBuilder
 	gengine     *Gengine
+	start       time.Time // when the engine is taken by the current execution
 
 	addition bool // when gengine resource is not enough and poollength >  minPool  and  poollength < maxPool, new gengine will be create, and it will be tagged addition=true; when poollength <  minPool it will be tagged addition=false
 }
@@ -77,8 +108,8 @@ func NewGenginePool(poolMinLen, poolMaxLen int64, em int, rulesStr string, apiOu
 		return nil, errors.New("pool length must be bigger than 0, and poolMaxLen must bigger than poolMinLen")
 	}
 
-	if em != SORT_MODEL && em != CONCOURRENT_MODEL && em != MIX_MODEL && em != INVERSE_MIX_MODEL {
-		return nil, errors.New(fmt.Sprintf("exec model must be SORT_MODEL(1) or CONCOURRENT_MODEL(2) or MIX_MODEL(3) or INVERSE_MIX_MODEL(4), now it is %d", em))
+	if e := checkExecModel(em); e != nil {
+		return nil, e
 	}
 
 	fg := make([]*gengineWrapper, poolMinLen)
@@ -114,8 +145,10 @@ func NewGenginePool(poolMinLen, poolMaxLen int64, em int, rulesStr string, apiOu
 	}
 
 	p := &GenginePool{
+		stats:            newPoolStats(),
 		ruleBuilder:      srcRb,
 		freeGengines:     fg,
+		inUse:            make(chan struct{}, poolMaxLen),
 		apis:             apiOuter,
 		execModel:        em,
 		additionNum:      poolMaxLen - poolMinLen,
@@ -129,7 +162,7 @@ func NewGenginePool(poolMinLen, poolMaxLen int64, em int, rulesStr string, apiOu
 
 //this could ensure make thread safety!
 func makeRuleBuilder(ruleStr string, apiOuter map[string]interface{}) (*builder.RuleBuilder, error) {
-	dataContext := context.NewDataContext()
+	dataContext := gcontext.NewDataContext()
 	if apiOuter != nil {
 		for k, v := range apiOuter {
 			dataContext.Add(k, v)
@@ -147,51 +180,69 @@ func makeRuleBuilder(ruleStr string, apiOuter map[string]interface{}) (*builder.
 	return rb, nil
 }
 
-// if there is no enough gengine source, no request will take a lock
-func (gp *GenginePool) getGengine() (*gengineWrapper, error) {
-
-	for {
-		gp.getEngineLock.Lock()
-		//check if there has enough resource in pool
-		numFree := len(gp.freeGengines)
-		if numFree > 0 {
-			gp.runningLock.Lock()
-			gw := gp.freeGengines[0]
-			gp.freeGengines = gp.freeGengines[1:]
-			gp.runningLock.Unlock()
-			gp.getEngineLock.Unlock()
-			return gw, nil
+/**
+take a free engine, the base engines first, then the addition ones,
+when all the engines are in use, it blocks until one is returned, ctx is done or the max wait is exceeded
+*/
+func (gp *GenginePool) getGengine(ctx context.Context) (*gengineWrapper, error) {
+	select {
+	case gp.inUse <- struct{}{}:
+		gp.stats.wait(0, nil)
+	default:
+		start := time.Now()
+		e := gp.waitGengine(ctx)
+		gp.stats.wait(time.Since(start), e)
+		if e != nil {
+			return nil, e
 		}
+	}
 
-		//check if there has addition resource
-		numAddition := len(gp.additionGengines)
-		if numAddition > 0 {
-			gp.additionLock.Lock()
-			gw := gp.additionGengines[0]
-			gp.additionGengines = gp.additionGengines[1:]
-			gp.additionLock.Unlock()
-			gp.getEngineLock.Unlock()
-			return gw, nil
-		}
+	//a token is taken, so there is at least one free engine
+	gp.freeLock.Lock()
+	defer gp.freeLock.Unlock()
+	if len(gp.freeGengines) > 0 {
+		gw := gp.freeGengines[0]
+		gp.freeGengines = gp.freeGengines[1:]
+		return gw, nil
+	}
+	gw := gp.additionGengines[0]
+	gp.additionGengines = gp.additionGengines[1:]
+	return gw, nil
+}
+
+//wait for a token when all the engines are in use
+func (gp *GenginePool) waitGengine(ctx context.Context) error {
+	gp.execLock.RLock()
+	maxWait := gp.maxWait
+	gp.execLock.RUnlock()
 
-		gp.getEngineLock.Unlock()
+	var timeout <-chan time.Time
+	if maxWait > 0 {
+		timer := time.NewTimer(maxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case gp.inUse <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", ErrPoolExhausted, ctx.Err())
+	case <-timeout:
+		return fmt.Errorf("%w: waited %v", ErrPoolExhausted, maxWait)
 	}
 }
 
-// async return gengine resource to pool,and update the rules
+//return the engine to the pool, one of the executions waiting for an engine takes it
 func (gp *GenginePool) putGengineLocked(gw *gengineWrapper) {
-	//addition resource
-	go func() {
-		if gw.addition {
-			gp.additionLock.Lock()
-			gp.additionGengines = append(gp.additionGengines, gw)
-			gp.additionLock.Unlock()
-		} else {
-			gp.runningLock.Lock()
-			gp.freeGengines = append(gp.freeGengines, gw)
-			gp.runningLock.Unlock()
-		}
-	}()
+	gp.freeLock.Lock()
+	if gw.addition {
+		gp.additionGengines = append(gp.additionGengines, gw)
+	} else {
+		gp.freeGengines = append(gp.freeGengines, gw)
+	}
+	gp.freeLock.Unlock()
+	<-gp.inUse
 }
 
 //sync method
@@ -224,11 +275,14 @@ func (gp *GenginePool) UpdatePooledRules(ruleStr string) error {
 			rbs[i] = rb
 		}
 
+		gp.rulesLock.Lock()
+		defer gp.rulesLock.Unlock()
 		//update instance
 		gp.rbSlice = rbs
 		//update core
 		gp.ruleBuilder = rbi
 		gp.clear = false
+		gp.stats.update(rbi.Kc.RuleEntities)
 		return nil
 	}
 }
@@ -250,11 +304,11 @@ func getKc(ruleString string) (*base.KnowledgeContext, error) {
 	antlr.ParseTreeWalkerDefault.Walk(listener, psr.Primary())
 
 	if len(errListener.GrammarErrors) > 0 {
-		return nil, errors.New(fmt.Sprintf("%+v", errListener.GrammarErrors))
+		return nil, &errors.MultiError{Errors: errListener.Errors}
 	}
 
 	if len(listener.ParseErrors) > 0 {
-		return nil, errors.New(fmt.Sprintf("%+v", listener.ParseErrors))
+		return nil, &errors.MultiError{Errors: listener.Errors}
 	}
 
 	if len(kc.RuleEntities) == 0 {
@@ -264,79 +318,16 @@ func getKc(ruleString string) (*base.KnowledgeContext, error) {
 	return kc, nil
 }
 
-func updateIncremental(kc *base.KnowledgeContext, rb *builder.RuleBuilder) {
-	//copy
-	newRuleEntities := make(map[string]*base.RuleEntity, len(rb.Kc.RuleEntities))
-	for mk, mv := range rb.Kc.RuleEntities {
-		newRuleEntities[mk] = mv
-	}
-
-	//copy
-	newSortRules := make([]*base.RuleEntity, len(rb.Kc.SortRules))
-	for sk, sv := range rb.Kc.SortRules {
-		newSortRules[sk] = sv
-	}
-
-	//kc store the new rules
-	for k, v := range kc.RuleEntities {
-		//init
+//a new rule builder with the rules of kc merged into rb, rb is not changed, the executions may still use it
+func updateIncremental(kc *base.KnowledgeContext, rb *builder.RuleBuilder) *builder.RuleBuilder {
+	//init
+	for _, v := range kc.RuleEntities {
 		v.Initialize(rb.Dc)
-
-		if vm, ok := newRuleEntities[k]; ok {
-			//repalce update
-			//search
-			index := rb.Kc.SortRulesIndexMap[v.RuleName]
-			if v.Salience == vm.Salience {
-				//replace
-				newSortRules[index] = v
-			} else {
-				newSortRules := append(newSortRules[:index], newSortRules[index+1:]...)
-				low, mid := tool.BinarySearch(newSortRules, v.Salience)
-
-				ire := []*base.RuleEntity{v}
-				if mid == 0 {
-					newRe := append(ire, newSortRules[low:]...)
-					newSortRules = append(newSortRules[:low], newRe...)
-				} else {
-					newRe := append(ire, newSortRules[mid:]...)
-					newSortRules = append(newSortRules[:mid], newRe...)
-				}
-
-				//update the sort index
-				indexMap := make(map[string]int)
-				for k, v := range newSortRules {
-					indexMap[v.RuleName] = k
-				}
-				rb.Kc.SortRulesIndexMap = indexMap
-			}
-
-			newRuleEntities[k] = v
-		} else {
-			//add update
-			low, mid := tool.BinarySearch(newSortRules, v.Salience)
-
-			ire := []*base.RuleEntity{v}
-			if mid == 0 {
-				newRe := append(ire, newSortRules[low:]...)
-				newSortRules = append(newSortRules[:low], newRe...)
-			} else {
-				newRe := append(ire, newSortRules[mid:]...)
-				newSortRules = append(newSortRules[:mid], newRe...)
-			}
-
-			//update the sort index
-			indexMap := make(map[string]int)
-			for k, v := range newSortRules {
-				indexMap[v.RuleName] = k
-			}
-			rb.Kc.SortRulesIndexMap = indexMap
-
-			newRuleEntities[k] = v
-		}
 	}
 
-	rb.Kc.RuleEntities = newRuleEntities
-	rb.Kc.SortRules = newSortRules
+	next := builder.NewRuleBuilder(rb.Dc)
+	next.Kc = rb.Kc.Merge(kc)
+	return next
 }
 
 //sync method
@@ -355,6 +346,16 @@ func (gp *GenginePool) UpdatePooledRulesIncremental(ruleStr string) error {
 	if e != nil {
 		return e
 	}
+	gp.rulesLock.RLock()
+	rbi, rbSlice := gp.ruleBuilder, gp.rbSlice
+	gp.rulesLock.RUnlock()
+	if rbi == nil {
+		//the rules has bean cleared
+		rbi = builder.NewRuleBuilder(gcontext.NewDataContext())
+	}
+	if e := base.CheckIncrementalDependencies(rbi.Kc, kci); e != nil {
+		return e
+	}
 
 	//new instance
 	kcs := make([]*base.KnowledgeContext, gp.max)
@@ -366,15 +367,21 @@ func (gp *GenginePool) UpdatePooledRulesIncremental(ruleStr string) error {
 		kcs[i] = kc
 	}
 
-	//update main
-	updateIncremental(kci, gp.ruleBuilder)
+	//new main
+	rbi = updateIncremental(kci, rbi)
 
-	//update instance
+	//new instance
+	rbs := make([]*builder.RuleBuilder, gp.max)
 	for i := 0; i < int(gp.max); i++ {
-		updateIncremental(kcs[i], gp.rbSlice[i])
+		rbs[i] = updateIncremental(kcs[i], rbSlice[i])
 	}
 
+	gp.rulesLock.Lock()
+	defer gp.rulesLock.Unlock()
+	gp.ruleBuilder = rbi
+	gp.rbSlice = rbs
 	gp.clear = false
+	gp.stats.update(rbi.Kc.RuleEntities)
 	return nil
 }
 
@@ -382,11 +389,18 @@ func (gp *GenginePool) UpdatePooledRulesIncremental(ruleStr string) error {
 func (gp *GenginePool) ClearPoolRules() {
 	gp.updateLock.Lock()
 	defer gp.updateLock.Unlock()
-	gp.ruleBuilder = nil
-	gp.clear = true
+
+	gp.rulesLock.Lock()
+	defer gp.rulesLock.Unlock()
+	//the executions may still use the old instances
+	rbs := make([]*builder.RuleBuilder, gp.max)
 	for i := 0; i < int(gp.max); i++ {
-		gp.rbSlice[i].Kc.ClearRules()
+		rbs[i] = builder.NewRuleBuilder(gp.rbSlice[i].Dc)
 	}
+	gp.rbSlice = rbs
+	gp.ruleBuilder = nil
+	gp.clear = true
+	gp.stats.update(nil)
 }
 
 /*
@@ -394,26 +408,164 @@ func (gp *GenginePool) ClearPoolRules() {
 2 concurrent model
 3 mix model
 4 inverse mix model
+5 forward chaining model
+6 DAG model
+7 salience layered model
+8 first match model
 */
 func (gp *GenginePool) SetExecModel(execModel int) error {
 	gp.updateLock.Lock()
 	defer gp.updateLock.Unlock()
-	if execModel != SORT_MODEL && execModel != CONCOURRENT_MODEL && execModel != MIX_MODEL && execModel != INVERSE_MIX_MODEL {
-		return errors.New(fmt.Sprintf("exec model must be SORT_MODEL(1) or CONCOURRENT_MODEL(2) or MIX_MODEL(3) or INVERSE_MIX_MODEL(4), now it is %d", execModel))
+	if e := checkExecModel(execModel); e != nil {
+		return e
 	} else {
+		gp.rulesLock.Lock()
 		gp.execModel = execModel
+		gp.rulesLock.Unlock()
 	}
 	return nil
 }
 
+//set the clock used by the builtin time.Now() in rules for all engines in the pool, nil means the system clock
+func (gp *GenginePool) SetClock(clock func() time.Time) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.clock = clock
+}
+
+//set the limits of every execution for all engines in the pool, see Gengine.SetLimits
+func (gp *GenginePool) SetLimits(limits Limits) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.limits = limits
+}
+
+//set the max cycles of the forward chaining model for all engines in the pool, see Gengine.SetMaxCycles
+func (gp *GenginePool) SetMaxCycles(n int64) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.maxCycles = n
+}
+
+//set the max number of goroutines of a concurrent step for all engines in the pool, see Gengine.SetMaxParallelism
+func (gp *GenginePool) SetMaxParallelism(n int) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.parallelism = n
+}
+
+//enable or disable fail fast for all engines in the pool, see Gengine.SetFailFast
+func (gp *GenginePool) SetFailFast(enable bool) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.failFast = enable
+}
+
+//enable or disable the transactional mode for all engines in the pool, see Gengine.SetTransactional
+func (gp *GenginePool) SetTransactional(enable bool) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.transaction = enable
+}
+
+/**
+set the max time an execution waits for a free engine when all the engines are in use, zero means no limit,
+when it is exceeded or the ctx of the execution is done before an engine is free, the execution returns ErrPoolExhausted
+*/
+func (gp *GenginePool) SetMaxWait(d time.Duration) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.maxWait = d
+}
+
+//apply the execution options of the pool to the engine
+func (gp *GenginePool) setupGengine(g *Gengine) {
+	gp.execLock.RLock()
+	defer gp.execLock.RUnlock()
+	g.SetClock(gp.clock)
+	g.SetLimits(gp.limits)
+	g.SetMaxCycles(gp.maxCycles)
+	g.SetMaxParallelism(gp.parallelism)
+	g.SetFailFast(gp.failFast)
+	g.SetTransactional(gp.transaction)
+	g.SetTraceHandler(gp.tracer)
+	g.SetReportHandler(gp.reporter)
+	g.SetDryRunHandler(gp.dryRunner)
+	g.listeners = gp.listeners
+}
+
+//register a listener for all engines in the pool, see Gengine.AddListener
+func (gp *GenginePool) AddListener(l Listener) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	listeners := make([]Listener, 0, len(gp.listeners)+1)
+	listeners = append(listeners, gp.listeners...)
+	gp.listeners = append(listeners, l)
+}
+
+/**
+enable tracing for all engines in the pool, handler is called with the trace of every execution
+before the execute method returns, ctx is the one given to the execute method, nil handler disables tracing
+see Gengine.SetTracing
+*/
+func (gp *GenginePool) SetTraceHandler(handler func(ctx context.Context, trace *Trace)) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.tracer = handler
+}
+
+/**
+enable reporting for all engines in the pool, handler is called with the report of every execution
+before the execute method returns, ctx is the one given to the execute method, nil handler disables reporting
+see Gengine.SetReporting
+*/
+func (gp *GenginePool) SetReportHandler(handler func(ctx context.Context, report *Report)) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.reporter = handler
+}
+
+/**
+enable dry run for all engines in the pool, handler is called with the change set of every execution
+before the execute method returns, ctx is the one given to the execute method, nil handler disables dry run
+see Gengine.SetDryRun
+*/
+func (gp *GenginePool) SetDryRunHandler(handler func(ctx context.Context, changes *ChangeSet)) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.dryRunner = handler
+}
+
+/**
+handler is called with the name of the matched rule of every first match execution before the execute method returns,
+the name is "" when no rule matched, ctx is the one given to the execute method, nil handler removes it
+it is how the executions with the model of the pool, like ExecuteRules, give the matched rule to the caller
+*/
+func (gp *GenginePool) SetMatchHandler(handler func(ctx context.Context, ruleName string)) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	gp.matcher = handler
+}
+
+func (gp *GenginePool) handleMatch(ctx context.Context, ruleName string) {
+	gp.execLock.RLock()
+	handler := gp.matcher
+	gp.execLock.RUnlock()
+	if handler != nil {
+		handler(ctx, ruleName)
+	}
+}
+
 func (gp *GenginePool) GetExecModel() int {
+	gp.rulesLock.RLock()
+	defer gp.rulesLock.RUnlock()
 	return gp.execModel
 }
 
 //check the rule whether exist
 func (gp *GenginePool) IsExist(ruleName string) bool {
-	gp.updateLock.Lock()
-	defer gp.updateLock.Unlock()
+	gp.rulesLock.RLock()
+	defer gp.rulesLock.RUnlock()
 
 	if gp.clear || gp.ruleBuilder == nil {
 		return false
@@ -423,8 +575,8 @@ func (gp *GenginePool) IsExist(ruleName string) bool {
 }
 
 func (gp *GenginePool) GetRulesNumber() int {
-	gp.updateLock.Lock()
-	defer gp.updateLock.Unlock()
+	gp.rulesLock.RLock()
+	defer gp.rulesLock.RUnlock()
 
 	if gp.clear || gp.ruleBuilder == nil {
 		return 0
@@ -432,33 +584,18 @@ func (gp *GenginePool) GetRulesNumber() int {
 	return len(gp.ruleBuilder.Kc.RuleEntities)
 }
 
-func (gp *GenginePool) prepare(reqName string, req interface{}, respName string, resp interface{}) (*gengineWrapper, error) {
-	//get gengine resource
-	gw, e := gp.getGengine()
-	if e != nil {
-		return nil, e
-	}
-
-	gw.rulebuilder = gp.rbSlice[gw.tag]
-
-	if reqName != "" && req != nil {
-		gw.rulebuilder.Dc.Add(reqName, req)
-	}
-
-	if respName != "" && resp != nil {
-		gw.rulebuilder.Dc.Add(respName, resp)
-	}
-	return gw, nil
-}
-
-func (gp *GenginePool) prepareWithMultiInput(data map[string]interface{}) (*gengineWrapper, error) {
+func (gp *GenginePool) prepareWithMultiInput(ctx context.Context, data map[string]interface{}) (*gengineWrapper, error) {
 	//get gengine resource
-	gw, e := gp.getGengine()
+	gw, e := gp.getGengine(ctx)
 	if e != nil {
 		return nil, e
 	}
 
+	gp.rulesLock.RLock()
 	gw.rulebuilder = gp.rbSlice[gw.tag]
+	gp.rulesLock.RUnlock()
+	gw.start = time.Now()
+	gp.setupGengine(gw.gengine)
 
 	for k, v := range data {
 		//user should not inject "" string or nil value
@@ -472,45 +609,130 @@ func (gp *GenginePool) prepareWithMultiInput(data map[string]interface{}) (*geng
 	return gw, nil
 }
 
-//execute rules as the user set execute model when init or update
-//req, it is better to be ptr, or you will not get changed data
-//resp, it is better to be ptr, or you will not get changed dat
-func (gp *GenginePool) ExecuteRules(reqName string, req interface{}, respName string, resp interface{}) error {
+/**
+take an engine, inject the data, execute the rules by run, then remove the data and return the engine,
+model is the name of the execution in the stats, matched is the rule matched by the first match model
+*/
+func (gp *GenginePool) execute(ctx context.Context, data map[string]interface{}, model string, run func(g *Gengine, rb *builder.RuleBuilder) (string, error)) (matched string, err error) {
 
+	gp.rulesLock.RLock()
+	clear := gp.clear
+	gp.rulesLock.RUnlock()
 	//rules has bean cleared
-	if gp.clear {
+	if clear {
 		//no data to execute rule
-		return nil
+		return "", nil
 	}
 
-	gw, e := gp.prepare(reqName, req, respName, resp)
+	gw, e := gp.prepareWithMultiInput(ctx, data)
 	if e != nil {
-		return e
+		return "", e
 	}
 	//release resource
 	defer func() {
-		gw.rulebuilder.Dc.Del(reqName, respName)
+		gw.clearInjected(getKeys(data)...)
+		gp.stats.execution(model, time.Since(gw.start), err)
 		gp.putGengineLocked(gw)
 	}()
 
-	if gp.execModel == SORT_MODEL { //sort
-		// when some rule execute error ,it will continue to execute last
-		return gw.gengine.Execute(gw.rulebuilder, true)
+	matched, err = run(gw.gengine, gw.rulebuilder)
+	if model == modelName(FIRST_MATCH_MODEL) {
+		gp.handleMatch(ctx, matched)
 	}
+	return matched, err
+}
 
-	if gp.execModel == CONCOURRENT_MODEL { //concurrent
-		return gw.gengine.ExecuteConcurrent(gw.rulebuilder)
+/**
+execute all the rules with the model, the stop tag is used by the models which support it, it may be nil,
+matched is the rule matched by the first match model
+*/
+func executeModel(ctx context.Context, g *Gengine, rb *builder.RuleBuilder, model int, stag *Stag) (string, error) {
+	switch model {
+	case SORT_MODEL:
+		// when some rule execute error ,it will continue to execute last
+		if stag != nil {
+			return "", g.ExecuteWithStopTagDirectWithContext(ctx, rb, true, stag)
+		}
+		return "", g.ExecuteWithContext(ctx, rb, true)
+	case CONCOURRENT_MODEL:
+		return "", g.ExecuteConcurrentWithContext(ctx, rb)
+	case MIX_MODEL:
+		if stag != nil {
+			return "", g.ExecuteMixModelWithStopTagDirectWithContext(ctx, rb, stag)
+		}
+		return "", g.ExecuteMixModelWithContext(ctx, rb)
+	case INVERSE_MIX_MODEL:
+		return "", g.ExecuteInverseMixModelWithContext(ctx, rb)
+	case FORWARD_CHAINING_MODEL:
+		return "", g.ExecuteForwardChainingWithContext(ctx, rb)
+	case DAG_MODEL:
+		return "", g.ExecuteDAGWithContext(ctx, rb)
+	case SALIENCE_LAYERED_MODEL:
+		if stag != nil {
+			return "", g.ExecuteSalienceLayeredWithStopTagDirectWithContext(ctx, rb, true, stag)
+		}
+		return "", g.ExecuteSalienceLayeredWithContext(ctx, rb, true)
+	case FIRST_MATCH_MODEL:
+		// only one rule is executed, so the stop tag is not needed
+		return g.ExecuteFirstMatchWithContext(ctx, rb)
 	}
+	return "", nil
+}
 
-	if gp.execModel == MIX_MODEL { //mix
-		return gw.gengine.ExecuteMixModel(gw.rulebuilder)
-	}
+// execute the selected rules with the model, matched is the rule matched by the first match model
+func executeSelectedModel(ctx context.Context, g *Gengine, rb *builder.RuleBuilder, model int, names []string) (string, error) {
+	switch model {
+	case SORT_MODEL:
+		return "", g.ExecuteSelectedRulesWithContext(ctx, rb, names)
+	case CONCOURRENT_MODEL:
+		return "", g.ExecuteSelectedRulesConcurrentWithContext(ctx, rb, names)
+	case MIX_MODEL:
+		return "", g.ExecuteSelectedRulesMixModelWithContext(ctx, rb, names)
+	case INVERSE_MIX_MODEL:
+		return "", g.ExecuteSelectedRulesInverseMixModelWithContext(ctx, rb, names)
+	case FORWARD_CHAINING_MODEL:
+		return "", g.ExecuteSelectedRulesForwardChainingWithContext(ctx, rb, names)
+	case DAG_MODEL:
+		return "", g.ExecuteSelectedRulesDAGWithContext(ctx, rb, names)
+	case SALIENCE_LAYERED_MODEL:
+		return "", g.ExecuteSelectedRulesSalienceLayeredWithContext(ctx, rb, true, names)
+	case FIRST_MATCH_MODEL:
+		return g.ExecuteSelectedRulesFirstMatchWithContext(ctx, rb, names)
+	}
+	return "", nil
+}
 
-	if gp.execModel == INVERSE_MIX_MODEL { // inverse mix model
-		return gw.gengine.ExecuteInverseMixModel(gw.rulebuilder)
+// execute all the rules with the model of the pool
+func (gp *GenginePool) executeRules(ctx context.Context, data map[string]interface{}, stag *Stag) error {
+	model := gp.GetExecModel()
+	_, e := gp.execute(ctx, data, modelName(model), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return executeModel(ctx, g, rb, model, stag)
+	})
+	return e
+}
+
+// the request and the response as the data to inject, the empty names and nil values are not injected
+func requestData(reqName string, req interface{}, respName string, resp interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+	if reqName != "" && req != nil {
+		data[reqName] = req
 	}
+	if respName != "" && resp != nil {
+		data[respName] = resp
+	}
+	return data
+}
 
-	return nil
+//execute rules as the user set execute model when init or update
+//req, it is better to be ptr, or you will not get changed data
+//resp, it is better to be ptr, or you will not get changed dat
+func (gp *GenginePool) ExecuteRules(reqName string, req interface{}, respName string, resp interface{}) error {
+	return gp.ExecuteRulesWithContext(context.Background(), reqName, req, respName, resp)
+}
+
+// the same as ExecuteRules, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteRulesWithContext(ctx context.Context, reqName string, req interface{}, respName string, resp interface{}) error {
+	return gp.executeRules(ctx, requestData(reqName, req, respName, resp), nil)
 }
 
 /**
@@ -519,42 +741,12 @@ user can input more data to use in engine
 it is no difference with ExecuteRules, you just can inject more data use this api
 */
 func (gp *GenginePool) ExecuteRulesWithMultiInput(data map[string]interface{}) error {
+	return gp.ExecuteRulesWithMultiInputWithContext(context.Background(), data)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	if gp.execModel == SORT_MODEL { //sort
-		// when some rule execute error ,it will continue to execute last
-		return gw.gengine.Execute(gw.rulebuilder, true)
-	}
-
-	if gp.execModel == CONCOURRENT_MODEL { //concurrent
-		return gw.gengine.ExecuteConcurrent(gw.rulebuilder)
-	}
-
-	if gp.execModel == MIX_MODEL { //mix
-		return gw.gengine.ExecuteMixModel(gw.rulebuilder)
-	}
-
-	if gp.execModel == INVERSE_MIX_MODEL { // inverse mix model
-		return gw.gengine.ExecuteInverseMixModel(gw.rulebuilder)
-	}
-
-	return nil
-
+// the same as ExecuteRulesWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteRulesWithMultiInputWithContext(ctx context.Context, data map[string]interface{}) error {
+	return gp.executeRules(ctx, data, nil)
 }
 
 /**
@@ -566,282 +758,175 @@ if you want to know more about stag, to see the note above every method in Gengi
 //req, it is better to be ptr, or you will not get changed data
 //resp, it is better to be ptr, or you will not get changed data
 func (gp *GenginePool) ExecuteRulesWithStopTag(reqName string, req interface{}, respName string, resp interface{}, stag *Stag) error {
+	return gp.ExecuteRulesWithStopTagWithContext(context.Background(), reqName, req, respName, resp, stag)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepare(reqName, req, respName, resp)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(reqName, reqName)
-		gp.putGengineLocked(gw)
-	}()
-
-	if gp.execModel == SORT_MODEL { //sort
-		// when some rule execute error ,it will continue to execute last
-		return gw.gengine.ExecuteWithStopTagDirect(gw.rulebuilder, true, stag)
-	}
-
-	if gp.execModel == CONCOURRENT_MODEL { //concurrent
-		return gw.gengine.ExecuteConcurrent(gw.rulebuilder)
-	}
-
-	if gp.execModel == MIX_MODEL { //mix
-		return gw.gengine.ExecuteMixModelWithStopTagDirect(gw.rulebuilder, stag)
-	}
-
-	if gp.execModel == INVERSE_MIX_MODEL { // inverse mix model
-		return gw.gengine.ExecuteInverseMixModel(gw.rulebuilder)
-	}
-
-	return nil
+// the same as ExecuteRulesWithStopTag, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteRulesWithStopTagWithContext(ctx context.Context, reqName string, req interface{}, respName string, resp interface{}, stag *Stag) error {
+	return gp.executeRules(ctx, requestData(reqName, req, respName, resp), stag)
 }
 
 func (gp *GenginePool) ExecuteRulesWithMultiInputAndStopTag(data map[string]interface{}, stag *Stag) error {
+	return gp.ExecuteRulesWithMultiInputAndStopTagWithContext(context.Background(), data, stag)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	if gp.execModel == SORT_MODEL { //sort
-		// when some rule execute error ,it will continue to execute last
-		return gw.gengine.ExecuteWithStopTagDirect(gw.rulebuilder, true, stag)
-	}
-
-	if gp.execModel == CONCOURRENT_MODEL { //concurrent
-		return gw.gengine.ExecuteConcurrent(gw.rulebuilder)
-	}
-
-	if gp.execModel == MIX_MODEL { //mix
-		return gw.gengine.ExecuteMixModelWithStopTagDirect(gw.rulebuilder, stag)
-	}
-
-	if gp.execModel == INVERSE_MIX_MODEL { // inverse mix model
-		return gw.gengine.ExecuteInverseMixModel(gw.rulebuilder)
-	}
-
-	return nil
+// the same as ExecuteRulesWithMultiInputAndStopTag, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteRulesWithMultiInputAndStopTagWithContext(ctx context.Context, data map[string]interface{}, stag *Stag) error {
+	return gp.executeRules(ctx, data, stag)
 }
 
 /**
 see ExecuteSelectedRules in gengine.go
 */
 func (gp *GenginePool) ExecuteSelectedRulesWithMultiInput(data map[string]interface{}, names []string) error {
+	return gp.ExecuteSelectedRulesWithMultiInputWithContext(context.Background(), data, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteSelectedRules(gw.rulebuilder, names)
+// the same as ExecuteSelectedRulesWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(SORT_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesWithContext(ctx, rb, names)
+	})
+	return e
 }
 
 /**
 see ExecuteSelectedRulesWithControl in gengine.go
 */
 func (gp *GenginePool) ExecuteSelectedRulesWithControlWithMultiInput(data map[string]interface{}, b bool, names []string) error {
+	return gp.ExecuteSelectedRulesWithControlWithMultiInputWithContext(context.Background(), data, b, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteSelectedRulesWithControl(gw.rulebuilder, b, names)
+// the same as ExecuteSelectedRulesWithControlWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesWithControlWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, b bool, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(SORT_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesWithControlWithContext(ctx, rb, b, names)
+	})
+	return e
 }
 
 /**
 see ExecuteSelectedRulesWithControlAndStopTag in gengine.go
 */
 func (gp *GenginePool) ExecuteSelectedRulesWithControlAndStopTagWithMultiInput(data map[string]interface{}, b bool, stag *Stag, names []string) error {
+	return gp.ExecuteSelectedRulesWithControlAndStopTagWithMultiInputWithContext(context.Background(), data, b, stag, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteSelectedRulesWithControlAndStopTag(gw.rulebuilder, b, stag, names)
+// the same as ExecuteSelectedRulesWithControlAndStopTagWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesWithControlAndStopTagWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, b bool, stag *Stag, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(SORT_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesWithControlAndStopTagWithContext(ctx, rb, b, stag, names)
+	})
+	return e
 }
 
 /**
 see ExecuteSelectedRulesConcurrent in gengine.go
 */
 func (gp *GenginePool) ExecuteSelectedRulesConcurrentWithMultiInput(data map[string]interface{}, names []string) error {
+	return gp.ExecuteSelectedRulesConcurrentWithMultiInputWithContext(context.Background(), data, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteSelectedRulesConcurrent(gw.rulebuilder, names)
+// the same as ExecuteSelectedRulesConcurrentWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesConcurrentWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(CONCOURRENT_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesConcurrentWithContext(ctx, rb, names)
+	})
+	return e
 }
 
 /**
 see ExecuteSelectedRulesMixModel in gengine.go
 */
 func (gp *GenginePool) ExecuteSelectedRulesMixModelWithMultiInput(data map[string]interface{}, names []string) error {
+	return gp.ExecuteSelectedRulesMixModelWithMultiInputWithContext(context.Background(), data, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteSelectedRulesMixModel(gw.rulebuilder, names)
+// the same as ExecuteSelectedRulesMixModelWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesMixModelWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(MIX_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesMixModelWithContext(ctx, rb, names)
+	})
+	return e
 }
 
 // see ExecuteInverseMixModel in gengine.go
 func (gp *GenginePool) ExecuteInverseMixModelWithMultiInput(data map[string]interface{}) error {
+	return gp.ExecuteInverseMixModelWithMultiInputWithContext(context.Background(), data)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
-
-	return gw.gengine.ExecuteInverseMixModel(gw.rulebuilder)
+// the same as ExecuteInverseMixModelWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteInverseMixModelWithMultiInputWithContext(ctx context.Context, data map[string]interface{}) error {
+	_, e := gp.execute(ctx, data, modelName(INVERSE_MIX_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteInverseMixModelWithContext(ctx, rb)
+	})
+	return e
 }
 
 //see ExecuteInverseMixModelWithSelected in gengine.go
 func (gp *GenginePool) ExecuteSelectedRulesInverseMixModelWithMultiInput(data map[string]interface{}, names []string) error {
+	return gp.ExecuteSelectedRulesInverseMixModelWithMultiInputWithContext(context.Background(), data, names)
+}
 
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
-
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
+// the same as ExecuteSelectedRulesInverseMixModelWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesInverseMixModelWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, names []string) error {
+	_, e := gp.execute(ctx, data, modelName(INVERSE_MIX_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteSelectedRulesInverseMixModelWithContext(ctx, rb, names)
+	})
+	return e
+}
 
-	return gw.gengine.ExecuteSelectedRulesInverseMixModel(gw.rulebuilder, names)
+// see ExecuteFirstMatch in gengine.go, it returns the name of the matched rule
+func (gp *GenginePool) ExecuteFirstMatchWithMultiInput(data map[string]interface{}) (string, error) {
+	return gp.ExecuteFirstMatchWithMultiInputWithContext(context.Background(), data)
 }
 
-/***
-this make user could use exemodel to control the select-exemodel
-*/
-func (gp *GenginePool) ExecuteSelected(data map[string]interface{}, names []string) error {
-	//rules has bean cleared
-	if gp.clear {
-		//no data to execute rule
-		return nil
-	}
+// the same as ExecuteFirstMatchWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteFirstMatchWithMultiInputWithContext(ctx context.Context, data map[string]interface{}) (string, error) {
+	return gp.execute(ctx, data, modelName(FIRST_MATCH_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return g.ExecuteFirstMatchWithContext(ctx, rb)
+	})
+}
 
-	gw, e := gp.prepareWithMultiInput(data)
-	if e != nil {
-		return e
-	}
-	//release resource
-	defer func() {
-		gw.clearInjected(getKeys(data)...)
-		gp.putGengineLocked(gw)
-	}()
+// see ExecuteSelectedRulesFirstMatch in gengine.go, it returns the name of the matched rule
+func (gp *GenginePool) ExecuteSelectedRulesFirstMatchWithMultiInput(data map[string]interface{}, names []string) (string, error) {
+	return gp.ExecuteSelectedRulesFirstMatchWithMultiInputWithContext(context.Background(), data, names)
+}
 
-	if gp.execModel == SORT_MODEL {
-		return gw.gengine.ExecuteSelectedRules(gw.rulebuilder, names)
-	}
+// the same as ExecuteSelectedRulesFirstMatchWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedRulesFirstMatchWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, names []string) (string, error) {
+	return gp.execute(ctx, data, modelName(FIRST_MATCH_MODEL), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return g.ExecuteSelectedRulesFirstMatchWithContext(ctx, rb, names)
+	})
+}
 
-	if gp.execModel == CONCOURRENT_MODEL {
-		return gw.gengine.ExecuteSelectedRulesConcurrent(gw.rulebuilder, names)
-	}
+// see ExecuteGroups in group.go, the groups are executed with their own models instead of the model of the pool
+func (gp *GenginePool) ExecuteGroupsWithMultiInput(data map[string]interface{}, b bool, groups []GroupModel) error {
+	return gp.ExecuteGroupsWithMultiInputWithContext(context.Background(), data, b, groups)
+}
 
-	if gp.execModel == MIX_MODEL {
-		return gw.gengine.ExecuteSelectedRulesMixModel(gw.rulebuilder, names)
-	}
+// the same as ExecuteGroupsWithMultiInput, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteGroupsWithMultiInputWithContext(ctx context.Context, data map[string]interface{}, b bool, groups []GroupModel) error {
+	_, e := gp.execute(ctx, data, "groups", func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return "", g.ExecuteGroupsWithContext(ctx, rb, b, groups)
+	})
+	return e
+}
 
-	if gp.execModel == INVERSE_MIX_MODEL {
-		return gw.gengine.ExecuteSelectedRulesInverseMixModel(gw.rulebuilder, names)
-	}
+/***
+this make user could use exemodel to control the select-exemodel
+*/
+func (gp *GenginePool) ExecuteSelected(data map[string]interface{}, names []string) error {
+	return gp.ExecuteSelectedWithContext(context.Background(), data, names)
+}
 
-	return nil
+// the same as ExecuteSelected, but it stops when ctx is done, see Gengine.ExecuteWithContext
+func (gp *GenginePool) ExecuteSelectedWithContext(ctx context.Context, data map[string]interface{}, names []string) error {
+	model := gp.GetExecModel()
+	_, e := gp.execute(ctx, data, modelName(model), func(g *Gengine, rb *builder.RuleBuilder) (string, error) {
+		return executeSelectedModel(ctx, g, rb, model, names)
+	})
+	return e
 }
 
 func getKeys(data map[string]interface{}) []string {
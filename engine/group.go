@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"gengine/builder"
+	"gengine/internal/base"
+	"gengine/internal/core/errors"
+)
+
+// a group of rules declared by `group "pricing"` in the headers, and the model to execute it, such as SORT_MODEL
+type GroupModel struct {
+	Group string
+	Model int
+}
+
+/**
+execute the groups one by one in the given order, each group with its own model,
+the rules of a group are the rules with the group in their headers, "" means the rules without group
+
+the models are the ones of the pool, the sort model continues after the rule errors,
+and the salience layered model continues after the layer errors
+
+when b is true, if some rules of a group execute error, continue to execute the groups after it;
+when ctx is done, the budget is exceeded or a rule halts, the groups after it are not executed
+*/
+func (g *Gengine) ExecuteGroups(rb *builder.RuleBuilder, b bool, groups []GroupModel) error {
+	return g.ExecuteGroupsWithContext(context.Background(), rb, b, groups)
+}
+
+// the same as ExecuteGroups, but it stops when ctx is done
+func (g *Gengine) ExecuteGroupsWithContext(ctx context.Context, rb *builder.RuleBuilder, b bool, groups []GroupModel) (err error) {
+	ex := g.setup(ctx, rb)
+	defer func() { err = ex.finish(err) }()
+	ex.rules = nil
+
+	if len(groups) == 0 {
+		return selectError("no group has been selected to execute.")
+	}
+
+	var all []*base.RuleEntity
+	groupRules := make([][]*base.RuleEntity, len(groups))
+	for i, gm := range groups {
+		if e := checkExecModel(gm.Model); e != nil {
+			return errors.New(fmt.Sprintf("group \"%s\": %v", gm.Group, e))
+		}
+		// SortRules are sorted by priority
+		for _, r := range rb.Kc.SortRules {
+			if r.Group == gm.Group {
+				groupRules[i] = append(groupRules[i], r)
+			}
+		}
+		if len(groupRules[i]) == 0 {
+			return selectError(fmt.Sprintf("no rule in group \"%s\"", gm.Group))
+		}
+		all = append(all, groupRules[i]...)
+	}
+	ex.rules = all
+
+	var errs []error
+	for i, gm := range groups {
+		e := ex.executeGroup(rb, gm.Model, groupRules[i], g.getMaxCycles())
+		if e != nil {
+			if isStopped(e) {
+				return e
+			}
+			var ffe *FailFastError
+			if errors.As(e, &ffe) {
+				return e
+			}
+			if me, ok := e.(*MultiError); ok {
+				errs = append(errs, me.Errors...)
+			} else {
+				errs = append(errs, e)
+			}
+			if !b {
+				break
+			}
+		}
+		if ex.isHalted() {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
+	}
+	return nil
+}
+
+// execute the rules of a group with the model, the rules are sorted by priority
+func (ex *execution) executeGroup(rb *builder.RuleBuilder, model int, rules []*base.RuleEntity, maxCycles int64) error {
+	switch model {
+	case SORT_MODEL:
+		var errs []error
+		for _, r := range rules {
+			e := ex.executeRule(r)
+			if e != nil {
+				if isStopped(e) {
+					return e
+				}
+				errs = append(errs, e)
+			}
+		}
+		if len(errs) > 0 {
+			return &MultiError{Errors: errs}
+		}
+		return nil
+
+	case CONCOURRENT_MODEL:
+		errs, stopped := ex.executeConcurrent(rules)
+		if stopped != nil {
+			return stopped
+		}
+		if len(errs) > 0 {
+			return &MultiError{Errors: errs}
+		}
+		return nil
+
+	case MIX_MODEL:
+		e := ex.executeRule(rules[0])
+		if e != nil || len(rules) == 1 {
+			return e
+		}
+		errs, stopped := ex.executeConcurrent(rules[1:])
+		if stopped != nil {
+			return stopped
+		}
+		if len(errs) > 0 {
+			return &MultiError{Errors: errs}
+		}
+		return nil
+
+	case INVERSE_MIX_MODEL:
+		return ex.executeInverseMix(rules)
+
+	case FORWARD_CHAINING_MODEL:
+		return ex.executeForwardChaining(rules, maxCycles)
+
+	case DAG_MODEL:
+		return ex.executeDAG(rules)
+
+	case SALIENCE_LAYERED_MODEL:
+		return ex.executeSalienceLayered(rules, true, nil)
+
+	case FIRST_MATCH_MODEL:
+		_, e := ex.executeFirstMatch(rules)
+		return e
+	}
+	return errors.New(fmt.Sprintf("unknown exec model %d", model))
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"context"
+	"time"
+)
+
+/**
+Listener is called around every execution and every rule, in all execution models,
+register it with Gengine.AddListener or GenginePool.AddListener
+
+in the concurrent models the rule callbacks are called from many goroutines at the same time,
+so the listener must be safe for concurrent use
+*/
+type Listener interface {
+	// called before the first rule of an execution
+	BeforeExecute(ctx context.Context)
+	// called before a rule is executed, return false to veto it, then the rule is skipped and AfterRule is not called
+	BeforeRule(ctx context.Context, ruleName string) bool
+	// called after a rule is executed, err is the error of the rule
+	AfterRule(ctx context.Context, ruleName string, err error, duration time.Duration)
+	// called when an execution finishes, err is the error returned by the execute method
+	AfterExecute(ctx context.Context, err error)
+}
+
+// NopListener does nothing and vetoes nothing, embed it to implement only the callbacks you need
+type NopListener struct{}
+
+func (NopListener) BeforeExecute(ctx context.Context) {
+}
+
+func (NopListener) BeforeRule(ctx context.Context, ruleName string) bool {
+	return true
+}
+
+func (NopListener) AfterRule(ctx context.Context, ruleName string, err error, duration time.Duration) {
+}
+
+func (NopListener) AfterExecute(ctx context.Context, err error) {
+}
@@ -0,0 +1,301 @@
+package engine
+
+import (
+	"context"
+	"gengine/internal/base"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// the upper bounds of the buckets of the wait time histogram of a pool
+var WaitBuckets = []time.Duration{
+	0,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+/**
+a snapshot of the engines and the counters of a pool, see GenginePool.Stats
+
+the executions are counted by the names of their models, such as "sort", "concurrent" or "groups",
+an execution is counted when it gets an engine, and the wait for the engine is counted in WaitTime
+*/
+type PoolStats struct {
+	BaseInUse     int // the base engines, the pool always has poolMinLen of them
+	BaseFree      int
+	AdditionInUse int // the addition engines, poolMaxLen - poolMinLen of them
+	AdditionFree  int
+
+	WaitTime  WaitHistogram
+	Exhausted int64 // the executions which did not get an engine, they returned ErrPoolExhausted
+
+	Executions map[string]int64
+	Errors     map[string]int64     // the executions which returned an error
+	Rules      map[string]RuleStats // empty unless the rules are counted, see GenginePool.SetRuleStats
+
+	Version   int64     // the version of the rules, it is 1 when the pool is created and increased by every update
+	UpdatedAt time.Time // the time of the last update, or of the creation of the pool
+}
+
+/**
+the times the executions waited for a free engine, Counts[i] is the number of the waits which are not longer than Bounds[i]
+and longer than Bounds[i-1], the last count is the number of the waits longer than the last bound
+*/
+type WaitHistogram struct {
+	Bounds []time.Duration
+	Counts []int64
+	Sum    time.Duration
+}
+
+// the counters of a rule, the rules vetoed by a listener or with a false when condition are not counted
+type RuleStats struct {
+	Executions int64
+	Errors     int64
+	Total      time.Duration // the total time of the executions
+	Max        time.Duration // the longest execution
+}
+
+/**
+MetricsSink receives the metrics of a pool as they happen, register it with GenginePool.SetMetricsSink
+to export them to a metrics system, such as prometheus
+
+it is called in the executions, so it should be fast and safe for concurrent use
+*/
+type MetricsSink interface {
+	// an execution waited for an engine, err is ErrPoolExhausted when it did not get one
+	ObserveWait(wait time.Duration, err error)
+	// an execution of the model finished, err is the error returned by the execute method
+	ObserveExecution(model string, duration time.Duration, err error)
+	// a rule is executed, err is the error of the rule, it is called only when the rules are counted, see GenginePool.SetRuleStats
+	ObserveRule(ruleName string, duration time.Duration, err error)
+	// the rules of the pool are updated to the version
+	ObserveUpdate(version int64, at time.Time)
+}
+
+var modelNames = map[int]string{
+	SORT_MODEL:             "sort",
+	CONCOURRENT_MODEL:      "concurrent",
+	MIX_MODEL:              "mix",
+	INVERSE_MIX_MODEL:      "inverse_mix",
+	FORWARD_CHAINING_MODEL: "forward_chaining",
+	DAG_MODEL:              "dag",
+	SALIENCE_LAYERED_MODEL: "salience_layered",
+	FIRST_MATCH_MODEL:      "first_match",
+}
+
+// the name of the model in PoolStats.Executions
+func modelName(model int) string {
+	return modelNames[model]
+}
+
+/**
+the counters of a pool, they are updated atomically, so the executions do not wait for each other,
+it is the first listener of the engines of the pool when the rules are counted, see GenginePool.SetRuleStats
+*/
+type poolStats struct {
+	NopListener
+
+	waitCounts []int64
+	waitSum    int64 // in nanoseconds
+	exhausted  int64
+	models     sync.Map     // model name -> *modelCounters
+	rules      sync.Map     // rule name -> *ruleCounters
+	sink       atomic.Value //sinkRef
+
+	lock      sync.Mutex // it guards the version and the time of the update
+	version   int64
+	updatedAt time.Time
+}
+
+type modelCounters struct {
+	executions int64
+	errors     int64
+}
+
+type ruleCounters struct {
+	executions int64
+	errors     int64
+	total      int64 // in nanoseconds
+	max        int64
+}
+
+// the sink in atomic.Value, which can not store nil
+type sinkRef struct {
+	sink MetricsSink
+}
+
+func newPoolStats() *poolStats {
+	s := &poolStats{
+		waitCounts: make([]int64, len(WaitBuckets)+1),
+		version:    1,
+		updatedAt:  time.Now(),
+	}
+	s.sink.Store(sinkRef{})
+	return s
+}
+
+func (s *poolStats) getSink() MetricsSink {
+	return s.sink.Load().(sinkRef).sink
+}
+
+func (s *poolStats) wait(wait time.Duration, err error) {
+	i := 0
+	for i < len(WaitBuckets) && wait > WaitBuckets[i] {
+		i++
+	}
+	atomic.AddInt64(&s.waitCounts[i], 1)
+	atomic.AddInt64(&s.waitSum, int64(wait))
+	if err != nil {
+		atomic.AddInt64(&s.exhausted, 1)
+	}
+
+	if sink := s.getSink(); sink != nil {
+		sink.ObserveWait(wait, err)
+	}
+}
+
+func (s *poolStats) execution(model string, duration time.Duration, err error) {
+	c, ok := s.models.Load(model)
+	if !ok {
+		c, _ = s.models.LoadOrStore(model, &modelCounters{})
+	}
+	mc := c.(*modelCounters)
+	atomic.AddInt64(&mc.executions, 1)
+	if err != nil {
+		atomic.AddInt64(&mc.errors, 1)
+	}
+
+	if sink := s.getSink(); sink != nil {
+		sink.ObserveExecution(model, duration, err)
+	}
+}
+
+func (s *poolStats) AfterRule(ctx context.Context, ruleName string, err error, duration time.Duration) {
+	c, ok := s.rules.Load(ruleName)
+	if !ok {
+		c, _ = s.rules.LoadOrStore(ruleName, &ruleCounters{})
+	}
+	rc := c.(*ruleCounters)
+	atomic.AddInt64(&rc.executions, 1)
+	if err != nil {
+		atomic.AddInt64(&rc.errors, 1)
+	}
+	atomic.AddInt64(&rc.total, int64(duration))
+	for {
+		max := atomic.LoadInt64(&rc.max)
+		if int64(duration) <= max || atomic.CompareAndSwapInt64(&rc.max, max, int64(duration)) {
+			break
+		}
+	}
+
+	if sink := s.getSink(); sink != nil {
+		sink.ObserveRule(ruleName, duration, err)
+	}
+}
+
+// the rules are updated, the counters of the rules which are removed are dropped
+func (s *poolStats) update(rules map[string]*base.RuleEntity) {
+	s.rules.Range(func(name, _ interface{}) bool {
+		if _, ok := rules[name.(string)]; !ok {
+			s.rules.Delete(name)
+		}
+		return true
+	})
+
+	s.lock.Lock()
+	s.version++
+	s.updatedAt = time.Now()
+	version, at := s.version, s.updatedAt
+	s.lock.Unlock()
+
+	if sink := s.getSink(); sink != nil {
+		sink.ObserveUpdate(version, at)
+	}
+}
+
+// copy the counters into the snapshot
+func (s *poolStats) snapshot(stats *PoolStats) {
+	counts := make([]int64, len(s.waitCounts))
+	for i := range s.waitCounts {
+		counts[i] = atomic.LoadInt64(&s.waitCounts[i])
+	}
+	stats.WaitTime = WaitHistogram{
+		Bounds: append([]time.Duration{}, WaitBuckets...),
+		Counts: counts,
+		Sum:    time.Duration(atomic.LoadInt64(&s.waitSum)),
+	}
+	stats.Exhausted = atomic.LoadInt64(&s.exhausted)
+
+	stats.Executions = make(map[string]int64)
+	stats.Errors = make(map[string]int64)
+	s.models.Range(func(model, c interface{}) bool {
+		mc := c.(*modelCounters)
+		stats.Executions[model.(string)] = atomic.LoadInt64(&mc.executions)
+		stats.Errors[model.(string)] = atomic.LoadInt64(&mc.errors)
+		return true
+	})
+	stats.Rules = make(map[string]RuleStats)
+	s.rules.Range(func(name, c interface{}) bool {
+		rc := c.(*ruleCounters)
+		stats.Rules[name.(string)] = RuleStats{
+			Executions: atomic.LoadInt64(&rc.executions),
+			Errors:     atomic.LoadInt64(&rc.errors),
+			Total:      time.Duration(atomic.LoadInt64(&rc.total)),
+			Max:        time.Duration(atomic.LoadInt64(&rc.max)),
+		}
+		return true
+	})
+
+	s.lock.Lock()
+	stats.Version = s.version
+	stats.UpdatedAt = s.updatedAt
+	s.lock.Unlock()
+}
+
+/**
+a snapshot of the engines in use and free, and of the counters of the pool since it is created,
+the counters of the executions are kept when the rules are updated, the counters of the removed rules are dropped
+*/
+func (gp *GenginePool) Stats() *PoolStats {
+	stats := &PoolStats{}
+	gp.freeLock.Lock()
+	base := int(gp.max - gp.additionNum)
+	stats.BaseFree = len(gp.freeGengines)
+	stats.BaseInUse = base - stats.BaseFree
+	stats.AdditionFree = len(gp.additionGengines)
+	stats.AdditionInUse = int(gp.additionNum) - stats.AdditionFree
+	gp.freeLock.Unlock()
+
+	gp.stats.snapshot(stats)
+	return stats
+}
+
+// set the sink which receives the metrics of the pool as they happen, nil means no sink, see MetricsSink
+func (gp *GenginePool) SetMetricsSink(sink MetricsSink) {
+	gp.stats.sink.Store(sinkRef{sink: sink})
+}
+
+/**
+enable or disable the counters of the rules in PoolStats.Rules and MetricsSink.ObserveRule, they are disabled by default,
+when they are enabled the stats of the pool are the first listener of its engines
+*/
+func (gp *GenginePool) SetRuleStats(enable bool) {
+	gp.execLock.Lock()
+	defer gp.execLock.Unlock()
+	enabled := len(gp.listeners) > 0 && gp.listeners[0] == Listener(gp.stats)
+	if enable == enabled {
+		return
+	}
+	if enable {
+		gp.listeners = append([]Listener{gp.stats}, gp.listeners...)
+	} else {
+		gp.listeners = append([]Listener{}, gp.listeners[1:]...)
+	}
+}
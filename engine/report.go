@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"gengine/internal/base"
+	"sync"
+	"time"
+)
+
+// the status of a rule in one execution
+type RuleStatus string
+
+const (
+	// the rule has been executed without error
+	RuleExecuted RuleStatus = "executed"
+	// the rule has not been executed, its when condition is false or a listener vetoed it
+	RuleSkipped RuleStatus = "skipped"
+	// the rule, or its when condition, returned an error
+	RuleErrored RuleStatus = "errored"
+	// the rule has not been executed or has been aborted, because the execution stopped before it:
+	// a stop tag, halt, ctx done, the budget exceeded, or an error when the method does not continue on errors
+	RuleStopped RuleStatus = "stopped"
+)
+
+// the result of one rule, in the forward chaining model a rule may fire many times, Duration is the total
+type RuleResult struct {
+	RuleName string
+	Status   RuleStatus
+	Duration time.Duration
+	Err      error
+}
+
+/**
+the report of one execution, it lists every rule the execution was given, in the order they were given,
+see Gengine.SetReporting
+*/
+type Report struct {
+	lock    sync.Mutex
+	Rules   []*RuleResult
+	Err     error // the error returned by the execute method
+	results map[string]*RuleResult
+}
+
+func newReport() *Report {
+	return &Report{results: make(map[string]*RuleResult)}
+}
+
+// the result of the rule, nil when the rule was not given to the execution
+func (r *Report) Result(ruleName string) *RuleResult {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.results[ruleName]
+}
+
+// the names of the rules with the status, in the order of Rules
+func (r *Report) Names(status RuleStatus) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var names []string
+	for _, rr := range r.Rules {
+		if rr.Status == status {
+			names = append(names, rr.RuleName)
+		}
+	}
+	return names
+}
+
+/**
+record an outcome of the rule, a rule fired before keeps its status when it is skipped later,
+because in the forward chaining model its when condition is evaluated again
+*/
+func (r *Report) record(ruleName string, status RuleStatus, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	rr, ok := r.results[ruleName]
+	if !ok {
+		rr = &RuleResult{RuleName: ruleName}
+		r.results[ruleName] = rr
+	}
+	rr.Duration += duration
+	if status == RuleSkipped && rr.Status != "" && rr.Status != RuleSkipped {
+		return
+	}
+	rr.Status = status
+	if err != nil {
+		rr.Err = err
+	}
+}
+
+// the rules which have not been reached are stopped, the results are listed in the order of the rules
+func (r *Report) finish(rules []*base.RuleEntity, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Err = err
+	listed := make(map[string]bool, len(rules))
+	for _, re := range rules {
+		if listed[re.RuleName] {
+			continue
+		}
+		listed[re.RuleName] = true
+		rr, ok := r.results[re.RuleName]
+		if !ok {
+			rr = &RuleResult{RuleName: re.RuleName, Status: RuleStopped}
+			r.results[re.RuleName] = rr
+		}
+		r.Rules = append(r.Rules, rr)
+	}
+}
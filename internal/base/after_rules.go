@@ -0,0 +1,11 @@
+package base
+
+// the names of the rules declared by `after "ruleA", "ruleB"` in the header of a rule
+type AfterRules struct {
+	RuleNames []string
+}
+
+func (a *AfterRules) AcceptString(s string) error {
+	a.RuleNames = append(a.RuleNames, s)
+	return nil
+}
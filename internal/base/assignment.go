@@ -1,12 +1,9 @@
 package base
 
 import (
-	"fmt"
 	"gengine/context"
 	"gengine/internal/core"
 	"gengine/internal/core/errors"
-	"runtime"
-	"strings"
 )
 
 // := or =
@@ -17,6 +14,8 @@ type Assignment struct {
 	AssignOperator string
 	MathExpression *MathExpression
 	Expression     *Expression
+	Decimal        bool // decimal mode, numbers are calculated as core.Decimal
+	Overflow       int  // what to do when integer arithmetic overflows, see core.OverflowWrap
 	dataCtx        *context.DataContext
 }
 
@@ -24,16 +23,7 @@ func (a *Assignment) Evaluate(Vars map[string]interface{}) (value interface{}, e
 
 	defer func() {
 		if e := recover(); e != nil {
-			size := 1 << 10 * 10
-			buf := make([]byte, size)
-			rs := runtime.Stack(buf, false)
-			if rs > size {
-				rs = size
-			}
-			buf = buf[:rs]
-			eMsg := fmt.Sprintf("line %d, column %d, code: %s, %+v \n%s", a.LineNum, a.Column, a.Code, e, string(buf))
-			eMsg = strings.ReplaceAll(eMsg,"panic","error")
-			err = errors.New(eMsg)
+			err = a.codeError(errors.KindType, errors.NewPanicError(e))
 		}
 	}()
 
@@ -62,55 +52,63 @@ func (a *Assignment) Evaluate(Vars map[string]interface{}) (value interface{}, e
 	if len(a.Variable) > 0 {
 		sv, err = a.dataCtx.GetValue(Vars, a.Variable)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 	}
 
 	if a.MapVar != nil {
 		sv, err = a.MapVar.Evaluate(Vars)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 	}
 
+	if a.Decimal {
+		sv, mv = core.PromoteDecimal(sv, mv)
+	}
+
 	//var pv interface{}
 	if a.AssignOperator == "+=" {
-		mv, err = core.Add(sv, mv)
+		mv, err = core.CheckedAdd(sv, mv, a.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		goto END
 	}
 
 	if a.AssignOperator == "-=" {
-		mv, err = core.Sub(sv, mv)
+		mv, err = core.CheckedSub(sv, mv, a.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		goto END
 	}
 
 	if a.AssignOperator == "*=" {
-		mv, err = core.Mul(sv, mv)
+		mv, err = core.CheckedMul(sv, mv, a.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		goto END
 	}
 
 	if a.AssignOperator == "/=" {
-		mv, err = core.Div(sv, mv)
+		mv, err = core.CheckedDiv(sv, mv, a.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		goto END
 	}
 
 END:
+	if rt := a.dataCtx.Execution(Vars).RuleTrace(a.RuleName); rt != nil {
+		a.trace(rt, Vars, mv)
+	}
+
 	if len(a.Variable) > 0 {
 		err = a.dataCtx.SetValue(Vars, a.Variable, mv)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		return
 	}
@@ -118,7 +116,7 @@ END:
 	if a.MapVar != nil {
 		err = a.dataCtx.SetMapVarValue(Vars, a.MapVar.Name, a.MapVar.Strkey, a.MapVar.Varkey, a.MapVar.Intkey, mv)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column:%d, code: %s, %+v:", a.LineNum, a.Column, a.Code, err))
+			return nil, a.codeError(errors.KindType, err)
 		}
 		return
 	}
@@ -174,3 +172,21 @@ func (a *Assignment) AcceptExpression(exp *Expression) error {
 	}
 	return errors.New("Expression already set twice!")
 }
+
+// record the assignment, the old value is nil when the target is not defined yet
+func (a *Assignment) trace(rt *core.RuleTrace, Vars map[string]interface{}, mv interface{}) {
+	var old interface{}
+	target := a.Variable
+	func() {
+		defer func() {
+			recover()
+		}()
+		if len(a.Variable) > 0 {
+			old, _ = a.dataCtx.GetValue(Vars, a.Variable)
+		} else if a.MapVar != nil {
+			target = a.MapVar.Code
+			old, _ = a.MapVar.Evaluate(Vars)
+		}
+	}()
+	rt.Assignment(target, a.Code, a.LineNum, a.Column, old, mv)
+}
@@ -1,8 +1,32 @@
 package base
 
+import (
+	"context"
+	"gengine/internal/core/errors"
+)
+
 type SourceCode struct {
 	Code     string //raw code            -> ctx.GetText()
 	LineNum  int    //line number         -> ctx.GetStart().GetLine()
 	Column   int    //line start location -> ctx.GetStart().GetColumn()
 	LineStop int    //line end location   -> ctx.GetStop().GetColumn()
+	RuleName string //the rule the code belongs to
+}
+
+/**
+the error of the code, the errors of the inner code are returned as they are, so the position is the most precise one,
+and the errors which stop the execution are returned as they are too
+*/
+func (sc *SourceCode) codeError(kind string, err error) error {
+	var re *errors.RuleError
+	var be *errors.BudgetError
+	if errors.As(err, &re) || errors.As(err, &be) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &errors.RuleError{RuleName: sc.RuleName, LineNum: sc.LineNum, Column: sc.Column, Code: sc.Code, Kind: kind, Cause: err}
+}
+
+// the error of a panic recovered in the code, with the stack of the panic, call it in the deferred function
+func (sc *SourceCode) panicError(v interface{}) error {
+	return &errors.RuleError{RuleName: sc.RuleName, LineNum: sc.LineNum, Column: sc.Column, Code: sc.Code, Kind: errors.KindPanic, Cause: errors.NewPanicError(v)}
 }
@@ -1,19 +1,26 @@
 package base
 
 import (
-	"gengine/context"
+	"context"
+	gcontext "gengine/context"
+	"gengine/internal/core/errors"
 	"github.com/google/martian/v3/log"
 	"sync"
 )
 
+// the assignments, function calls and method calls in a conc block
+type concEvaluator interface {
+	Evaluate(Vars map[string]interface{}) (interface{}, error)
+}
+
 type ConcStatement struct {
 	Assignments   []*Assignment
 	FunctionCalls []*FunctionCall
 	MethodCalls   []*MethodCall
-	dataCtx       *context.DataContext
+	dataCtx       *gcontext.DataContext
 }
 
-func (cs *ConcStatement) Initialize(dc *context.DataContext) {
+func (cs *ConcStatement) Initialize(dc *gcontext.DataContext) {
 	cs.dataCtx = dc
 
 	if len(cs.Assignments) > 0 {
@@ -86,40 +93,64 @@ func (cs *ConcStatement) Evaluate(Vars map[string]interface{}) (interface{}, err
 		}
 
 	} else {
-		var wg sync.WaitGroup
-		wg.Add(l)
-		for _, assign := range cs.Assignments {
-			assignment := assign
-			go func() {
-				_, e := assignment.Evaluate(Vars)
-				if e != nil {
-					log.Errorf("concStatement Assignment err: %+v ", e)
-				}
-				wg.Done()
-			}()
+		//the first panic, budget or cancellation error of the statements, it is returned instead of crashing the process
+		//or letting the rule go past its limits, the other errors are only logged
+		var stopped error
+		var stopLock sync.Mutex
+		setStopped := func(e error) {
+			stopLock.Lock()
+			if stopped == nil {
+				stopped = e
+			}
+			stopLock.Unlock()
 		}
-		for _, fu := range cs.FunctionCalls {
-			fun := fu
-			go func() {
-				_, e := fun.Evaluate(Vars)
-				if e != nil {
-					log.Errorf("concStatement FunctionCall err: %+v ", e)
+		isStopped := func() bool {
+			stopLock.Lock()
+			defer stopLock.Unlock()
+			return stopped != nil
+		}
+		evaluate := func(sc *SourceCode, name string, stmt concEvaluator) {
+			defer func() {
+				if e := recover(); e != nil {
+					pe := sc.panicError(e)
+					log.Errorf("concStatement panic: %+v ", pe)
+					setStopped(pe)
 				}
-				wg.Done()
 			}()
+			_, e := stmt.Evaluate(Vars)
+			if e != nil {
+				log.Errorf("concStatement %s err: %+v ", name, e)
+				var pe *errors.PanicError
+				var be *errors.BudgetError
+				if errors.As(e, &pe) || errors.As(e, &be) || errors.Is(e, context.Canceled) || errors.Is(e, context.DeadlineExceeded) {
+					setStopped(e)
+				}
+			}
 		}
 
-		for _, me := range cs.MethodCalls {
-			meth := me
-			go func() {
-				_, e := meth.Evaluate(Vars)
-				if e != nil {
-					log.Errorf("concStatement MethodCall err: %+v ", e)
-				}
-				wg.Done()
-			}()
+		//the statements are evaluated by at most the max parallelism goroutines,
+		//no more statement is started once the execution is cancelled or a statement is stopped
+		exec := cs.dataCtx.Execution(Vars)
+		exec.Workers.Run(l, func(i int) bool {
+			if exec.ContextErr() != nil || isStopped() {
+				return false
+			}
+			if i < aLen {
+				evaluate(&cs.Assignments[i].SourceCode, "Assignment", cs.Assignments[i])
+			} else if i < aLen+fLen {
+				evaluate(&cs.FunctionCalls[i-aLen].SourceCode, "FunctionCall", cs.FunctionCalls[i-aLen])
+			} else {
+				evaluate(&cs.MethodCalls[i-aLen-fLen].SourceCode, "MethodCall", cs.MethodCalls[i-aLen-fLen])
+			}
+			return true
+		})
+
+		if e := exec.ContextErr(); e != nil {
+			return nil, e
+		}
+		if stopped != nil {
+			return nil, stopped
 		}
-		wg.Wait()
 	}
 	return nil, nil
 }
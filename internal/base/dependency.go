@@ -0,0 +1,125 @@
+package base
+
+import (
+	"fmt"
+	"gengine/internal/core/errors"
+	"sort"
+	"strings"
+)
+
+/**
+check the dependencies declared by `after`: every rule a rule is after must exist,
+and the rules must not depend on each other in a cycle
+*/
+func CheckDependencies(rules map[string]*RuleEntity) error {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(rules))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			i := len(path) - 1
+			for path[i] != name {
+				i--
+			}
+			return errors.New(fmt.Sprintf("rules dependency cycle: %s -> %s", strings.Join(path[i:], " -> "), name))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range rules[name].After {
+			if _, ok := rules[dep]; !ok {
+				return errors.New(fmt.Sprintf("rule \"%s\" is after the rule \"%s\" which does not exist", name, dep))
+			}
+			if e := visit(dep); e != nil {
+				return e
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range names {
+		if e := visit(name); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// check the dependencies of the rules after kc is incrementally updated into old, old is not changed
+func CheckIncrementalDependencies(old, kc *KnowledgeContext) error {
+	rules := make(map[string]*RuleEntity, len(old.RuleEntities)+len(kc.RuleEntities))
+	for k, v := range old.RuleEntities {
+		rules[k] = v
+	}
+	for k, v := range kc.RuleEntities {
+		rules[k] = v
+	}
+	return CheckDependencies(rules)
+}
+
+/**
+split the rules into layers by their dependencies: a rule is in the layer next to the deepest rule it is after,
+so the rules in the same layer do not depend on each other; the dependencies on the rules not in the given list are ignored,
+the order of the rules in a layer is the same as in the given list
+*/
+func Layers(rules []*RuleEntity) ([][]*RuleEntity, error) {
+	index := make(map[string]int, len(rules))
+	for i, r := range rules {
+		index[r.RuleName] = i
+	}
+
+	depth := make([]int, len(rules))
+	var visit func(i, n int) (int, error)
+	visit = func(i, n int) (int, error) {
+		if depth[i] > 0 {
+			return depth[i], nil
+		}
+		if n > len(rules) {
+			return 0, errors.New(fmt.Sprintf("rules dependency cycle at rule \"%s\"", rules[i].RuleName))
+		}
+		d := 1
+		for _, dep := range rules[i].After {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			dd, e := visit(j, n+1)
+			if e != nil {
+				return 0, e
+			}
+			if dd+1 > d {
+				d = dd + 1
+			}
+		}
+		depth[i] = d
+		return d, nil
+	}
+
+	var layers [][]*RuleEntity
+	for i, r := range rules {
+		d, e := visit(i, 0)
+		if e != nil {
+			return nil, e
+		}
+		for len(layers) < d {
+			layers = append(layers, nil)
+		}
+		layers[d-1] = append(layers[d-1], r)
+	}
+	return layers, nil
+}
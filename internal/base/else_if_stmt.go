@@ -13,7 +13,7 @@ type ElseIfStmt struct {
 }
 
 func (ef *ElseIfStmt) Evaluate(Vars map[string]interface{}) (interface{}, error) {
-	it, err := ef.Expression.Evaluate(Vars)
+	it, err := evaluateCondition("else if", ef.Expression, Vars)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package base
 import (
 	"fmt"
 	"gengine/context"
+	"gengine/internal/core"
 	"gengine/internal/core/errors"
 	"reflect"
 )
@@ -104,6 +105,8 @@ func (e *Expression) Evaluate(Vars map[string]interface{}) (interface{}, error)
 	}
 
 	var b interface{}
+	//the operands of comparison, for trace
+	var cmpLeft, cmpRight interface{}
 	if e.ExpressionRight == nil {
 		if e.ExpressionLeft != nil {
 			left, err := e.ExpressionLeft.Evaluate(Vars)
@@ -139,7 +142,7 @@ func (e *Expression) Evaluate(Vars map[string]interface{}) (interface{}, error)
 				b = flv.Bool() || frv.Bool()
 			}
 		} else {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, || or && can't be used between %s and %s:\n", e.LineNum, e.Column, e.Code, flv.Kind().String(), frv.Kind().String()))
+			return nil, e.codeError(errors.KindType, errors.New(fmt.Sprintf("|| or && can't be used between %s and %s", flv.Kind().String(), frv.Kind().String())))
 		}
 	}
 
@@ -155,6 +158,7 @@ func (e *Expression) Evaluate(Vars map[string]interface{}) (interface{}, error)
 		if err != nil {
 			return nil, err
 		}
+		cmpLeft, cmpRight = lv, rv
 
 		//
 		flv := reflect.ValueOf(lv)
@@ -184,62 +188,49 @@ func (e *Expression) Evaluate(Vars map[string]interface{}) (interface{}, error)
 				b = flv.String() <= frv.String()
 				break
 			default:
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, Can't be recognized ComparisonOperator: %s", e.LineNum, e.Column, e.Code, e.ComparisonOperator))
+				return nil, e.codeError(errors.KindType, errors.New(fmt.Sprintf("Can't be recognized ComparisonOperator: %s", e.ComparisonOperator)))
 			}
 			goto LAST
 		}
 
-		//data compare
-		if l, ok1 := TypeMap[tlv]; ok1 {
-			if r, ok2 := TypeMap[trv]; ok2 {
-				var ll float64
-				switch l {
-				case "int", "int8", "int16", "int32", "int64":
-					ll = float64(flv.Int())
-					break
-				case "uint", "uint8", "uint16", "uint32", "uint64":
-					ll = float64(flv.Uint())
-					break
-				case "float32", "float64":
-					ll = flv.Float()
-					break
-				}
+		//time compare
+		if c, ok := core.CompareTime(lv, rv); ok {
+			r, err := compareResult(e.ComparisonOperator, c)
+			if err != nil {
+				return nil, e.codeError(errors.KindType, err)
+			}
+			b = r
+			goto LAST
+		}
+
+		//decimal compare
+		if core.IsDecimal(lv) || core.IsDecimal(rv) {
+			c, err := core.CompareDecimal(lv, rv)
+			if err != nil {
+				return nil, e.codeError(errors.KindType, err)
+			}
+			r, err := compareResult(e.ComparisonOperator, c)
+			if err != nil {
+				return nil, e.codeError(errors.KindType, err)
+			}
+			b = r
+			goto LAST
+		}
 
-				var rr float64
-				switch r {
-				case "int", "int8", "int16", "int32", "int64":
-					rr = float64(frv.Int())
-					break
-				case "uint", "uint8", "uint16", "uint32", "uint64":
-					rr = float64(frv.Uint())
-					break
-				case "float32", "float64":
-					rr = frv.Float()
-					break
+		//data compare, integers are compared exactly, see core.CompareNumber
+		if _, ok1 := TypeMap[tlv]; ok1 {
+			if _, ok2 := TypeMap[trv]; ok2 {
+				c, comparable, err := core.CompareNumber(lv, rv)
+				if err != nil {
+					return nil, e.codeError(errors.KindType, err)
 				}
 
-				switch e.ComparisonOperator {
-				case "==":
-					b = ll == rr
-					break
-				case "!=":
-					b = ll != rr
-					break
-				case ">":
-					b = ll > rr
-					break
-				case "<":
-					b = ll < rr
-					break
-				case ">=":
-					b = ll >= rr
-					break
-				case "<=":
-					b = ll <= rr
-					break
-				default:
-					return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, Can't be recognized ComparisonOperator: %s", e.LineNum, e.Column, e.Code, e.ComparisonOperator))
+				r, err := compareResult(e.ComparisonOperator, c)
+				if err != nil {
+					return nil, e.codeError(errors.KindType, err)
 				}
+				//NaN is not equal to any number
+				b = comparable && r || !comparable && e.ComparisonOperator == "!="
 			}
 			goto LAST
 		}
@@ -253,13 +244,19 @@ func (e *Expression) Evaluate(Vars map[string]interface{}) (interface{}, error)
 				b = flv.Bool() != frv.Bool()
 				break
 			default:
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, Can't be recognized ComparisonOperator: %s", e.LineNum, e.Column, e.Code, e.ComparisonOperator))
+				return nil, e.codeError(errors.KindType, errors.New(fmt.Sprintf("Can't be recognized ComparisonOperator: %s", e.ComparisonOperator)))
 			}
 			goto LAST
 		}
 	}
 
 LAST:
+	if e.ComparisonOperator != "" && b != nil {
+		if rt := e.dataCtx.Execution(Vars).RuleTrace(e.RuleName); rt != nil {
+			rt.Operand(e.Code, cmpLeft, e.ComparisonOperator, cmpRight, b)
+		}
+	}
+
 	if e.NotOperator == "!" {
 
 		if math != nil {
@@ -286,5 +283,24 @@ LAST:
 			return b, nil
 		}
 	}
-	return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, evaluate Expression err!", e.LineNum, e.Column, e.Code))
+	return nil, e.codeError(errors.KindType, errors.New("evaluate Expression err!"))
+}
+
+// the result of the comparison operator on the result of a three way comparison, c < 0, c == 0 or c > 0
+func compareResult(op string, c int) (bool, error) {
+	switch op {
+	case "==":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case ">":
+		return c > 0, nil
+	case "<":
+		return c < 0, nil
+	case ">=":
+		return c >= 0, nil
+	case "<=":
+		return c <= 0, nil
+	}
+	return false, errors.New(fmt.Sprintf("Can't be recognized ComparisonOperator: %s", op))
 }
@@ -0,0 +1,85 @@
+package base
+
+import "strings"
+
+// collect the names of the facts read by the expression, see RuleEntity.Facts
+
+func (e *Expression) collectFacts(facts map[string]bool) {
+	if e == nil {
+		return
+	}
+	e.ExpressionLeft.collectFacts(facts)
+	e.ExpressionRight.collectFacts(facts)
+	e.ExpressionAtom.collectFacts(facts)
+	e.MathExpression.collectFacts(facts)
+}
+
+func (m *MathExpression) collectFacts(facts map[string]bool) {
+	if m == nil {
+		return
+	}
+	m.MathExpressionLeft.collectFacts(facts)
+	m.MathExpressionRight.collectFacts(facts)
+	m.ExpressionAtom.collectFacts(facts)
+}
+
+func (ea *ExpressionAtom) collectFacts(facts map[string]bool) {
+	if ea == nil {
+		return
+	}
+	if ea.Variable != "" {
+		facts[ea.Variable] = true
+	}
+	ea.FunctionCall.collectFacts(facts)
+	ea.MethodCall.collectFacts(facts)
+	ea.MapVar.collectFacts(facts)
+}
+
+func (m *MapVar) collectFacts(facts map[string]bool) {
+	if m == nil {
+		return
+	}
+	facts[m.Name] = true
+	if m.Varkey != "" {
+		facts[m.Varkey] = true
+	}
+}
+
+func (fc *FunctionCall) collectFacts(facts map[string]bool) {
+	if fc == nil {
+		return
+	}
+	fc.FunctionArgs.collectFacts(facts)
+}
+
+func (mc *MethodCall) collectFacts(facts map[string]bool) {
+	if mc == nil {
+		return
+	}
+	// the method may read any field of the object
+	facts[strings.Split(mc.MethodName, ".")[0]] = true
+	mc.MethodArgs.collectFacts(facts)
+}
+
+func (as *Args) collectFacts(facts map[string]bool) {
+	if as == nil {
+		return
+	}
+	for _, a := range as.ArgList {
+		if a.Variable != "" {
+			facts[a.Variable] = true
+		}
+		a.FunctionCall.collectFacts(facts)
+		a.MethodCall.collectFacts(facts)
+		a.MapVar.collectFacts(facts)
+		a.Expression.collectFacts(facts)
+	}
+}
+
+/**
+whether a change of the fact changed affects the fact read,
+"Order" affects "Order.Total" and the other way round
+*/
+func FactAffects(changed, read string) bool {
+	return changed == read || strings.HasPrefix(read, changed+".") || strings.HasPrefix(changed, read+".")
+}
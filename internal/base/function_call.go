@@ -1,11 +1,8 @@
 package base
 
 import (
-	"fmt"
 	"gengine/context"
 	"gengine/internal/core/errors"
-	"runtime"
-	"strings"
 )
 
 type FunctionCall struct {
@@ -32,16 +29,7 @@ func (fc *FunctionCall) Evaluate(Vars map[string]interface{}) (res interface{},
 
 	defer func() {
 		if e := recover(); e != nil {
-			size := 1 << 10 * 10
-			buf := make([]byte, size)
-			rs := runtime.Stack(buf, false)
-			if rs > size {
-				rs = size
-			}
-			buf = buf[:rs]
-			eMsg := fmt.Sprintf("line %d, column %d, code: %s, %+v \n%s", fc.LineNum, fc.Column, fc.Code, e, string(buf))
-			eMsg = strings.ReplaceAll(eMsg, "panic", "error")
-			err = errors.New(eMsg)
+			err = fc.codeError(errors.KindCall, errors.NewPanicError(e))
 		}
 	}()
 
@@ -56,9 +44,14 @@ func (fc *FunctionCall) Evaluate(Vars map[string]interface{}) (res interface{},
 		argumentValues = av
 	}
 
-	res, e := fc.dataCtx.ExecFunc(fc.FunctionName, argumentValues)
+	if be := fc.dataCtx.Execution(Vars).CountCall(); be != nil {
+		be.LineNum, be.Column, be.Code = fc.LineNum, fc.Column, fc.Code
+		return nil, be
+	}
+
+	res, e := fc.dataCtx.ExecFuncWithVars(Vars, fc.FunctionName, argumentValues)
 	if e != nil {
-		return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", fc.LineNum, fc.Column, fc.Code, e))
+		return nil, fc.codeError(errors.KindCall, e)
 	}
 	return //res, nil
 }
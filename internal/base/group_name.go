@@ -0,0 +1,19 @@
+package base
+
+import (
+	"fmt"
+	"gengine/internal/core/errors"
+)
+
+// the group declared by `group "pricing"` in the header of a rule
+type GroupName struct {
+	Name string
+}
+
+func (g *GroupName) AcceptString(s string) error {
+	if g.Name != "" {
+		return errors.New(fmt.Sprintf("group = %s set twice!", s))
+	}
+	g.Name = s
+	return nil
+}
@@ -16,7 +16,7 @@ type IfStmt struct {
 
 func (i *IfStmt) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 
-	it, err := i.Expression.Evaluate(Vars)
+	it, err := evaluateCondition("if", i.Expression, Vars)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func (i *IfStmt) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 
 		if i.ElseIfStmtList != nil {
 			for _, elseIfStmt := range i.ElseIfStmtList {
-				v, err := elseIfStmt.Expression.Evaluate(Vars)
+				v, err := evaluateCondition("else if", elseIfStmt.Expression, Vars)
 				if err != nil {
 					return nil, err
 				}
@@ -91,3 +91,17 @@ func (i *IfStmt) AcceptStatements(stmts *Statements) error {
 	}
 	return errors.New("ifStmt's statements set twice!")
 }
+
+// evaluate the condition of if or else if, record it when the execution is traced
+func evaluateCondition(kind string, expr *Expression, Vars map[string]interface{}) (interface{}, error) {
+	rt := expr.dataCtx.Execution(Vars).RuleTrace(expr.RuleName)
+	if rt == nil {
+		return expr.Evaluate(Vars)
+	}
+
+	c := rt.BeginCondition(kind, expr.Code, expr.LineNum, expr.Column)
+	v, err := expr.Evaluate(Vars)
+	result, _ := v.(bool)
+	rt.EndCondition(c, result)
+	return v, err
+}
@@ -1,8 +1,15 @@
 package base
 
+import "sort"
+
 type KnowledgeContext struct {
 	// ruleName - RuleEntity
-	RuleEntities      map[string]*RuleEntity
+	RuleEntities map[string]*RuleEntity
+	/**
+	the rules sorted by priority: the rules with higher salience first,
+	the rules with the same salience by their order in the sources (RuleEntity.Order), then by name,
+	so the order is the same between builds and the instances of a pool
+	*/
 	SortRules         []*RuleEntity
 	SortRulesIndexMap map[string]int
 }
@@ -20,3 +27,62 @@ func (k *KnowledgeContext) ClearRules() {
 	k.SortRules = make([]*RuleEntity, 0)
 	k.SortRulesIndexMap = make(map[string]int)
 }
+
+// rebuild SortRules and SortRulesIndexMap from RuleEntities
+func (k *KnowledgeContext) Sort() {
+	sortRules := make([]*RuleEntity, 0, len(k.RuleEntities))
+	for _, v := range k.RuleEntities {
+		sortRules = append(sortRules, v)
+	}
+	SortRules(sortRules)
+
+	indexMap := make(map[string]int, len(sortRules))
+	for i, v := range sortRules {
+		indexMap[v.RuleName] = i
+	}
+	k.SortRules = sortRules
+	k.SortRulesIndexMap = indexMap
+}
+
+/**
+the knowledge context with the rules of inc added to k, k is not changed:
+a rule of inc replaces the rule with the same name and keeps its order,
+the other rules of inc are after all the rules of k, by their order in inc
+*/
+func (k *KnowledgeContext) Merge(inc *KnowledgeContext) *KnowledgeContext {
+	next := 0
+	ruleEntities := make(map[string]*RuleEntity, len(k.RuleEntities)+len(inc.RuleEntities))
+	for name, v := range k.RuleEntities {
+		ruleEntities[name] = v
+		if v.Order >= next {
+			next = v.Order + 1
+		}
+	}
+
+	for name, v := range inc.RuleEntities {
+		if old, ok := k.RuleEntities[name]; ok {
+			v.Order = old.Order
+		} else {
+			v.Order += next
+		}
+		ruleEntities[name] = v
+	}
+
+	merged := &KnowledgeContext{RuleEntities: ruleEntities}
+	merged.Sort()
+	return merged
+}
+
+// sort the rules by priority, in the same order as KnowledgeContext.SortRules
+func SortRules(rules []*RuleEntity) {
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Salience != b.Salience {
+			return a.Salience > b.Salience
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return a.RuleName < b.RuleName
+	})
+}
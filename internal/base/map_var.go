@@ -1,7 +1,6 @@
 package base
 
 import (
-	"fmt"
 	"gengine/context"
 	"gengine/internal/core"
 	"gengine/internal/core/errors"
@@ -24,10 +23,14 @@ func (m *MapVar) Initialize(dc *context.DataContext) {
 }
 
 func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
+	//in dry run, the element may have been written
+	if v, ok := m.dataCtx.GetMapVarChange(Vars, m.Name, m.Strkey, m.Varkey, m.Intkey); ok {
+		return v, nil
+	}
 
 	value, e := m.dataCtx.GetValue(Vars, m.Name)
 	if e != nil {
-		return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+		return nil, m.codeError(errors.KindType, e)
 	}
 	typeName := reflect.TypeOf(value).String()
 
@@ -40,12 +43,12 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if len(m.Varkey) > 0 {
 			key, e := m.dataCtx.GetValue(Vars, m.Varkey)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 
 			wantedKey, e := core.GetWantedValue(key, keyType)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 			return reflect.ValueOf(value).MapIndex(reflect.ValueOf(wantedKey)).Interface(), nil
 		}
@@ -57,7 +60,7 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		//intKey
 		wantedKey, e := core.GetWantedValue(m.Intkey, keyType)
 		if e != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+			return nil, m.codeError(errors.KindType, e)
 		}
 
 		return reflect.ValueOf(value).MapIndex(reflect.ValueOf(wantedKey)).Interface(), nil
@@ -68,7 +71,7 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if len(m.Varkey) > 0 {
 			wantedKey, e := m.dataCtx.GetValue(Vars, m.Varkey)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 			return reflect.ValueOf(value).Index(int(reflect.ValueOf(wantedKey).Int())).Interface(), nil
 		}
@@ -76,7 +79,7 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if m.Intkey >= 0 {
 			return reflect.ValueOf(value).Index(int(m.Intkey)).Interface(), nil
 		} else {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, Slice or Array index must be non-negative!", m.LineNum, m.Column, m.Code))
+			return nil, m.codeError(errors.KindType, errors.New("Slice or Array index must be non-negative!"))
 		}
 	}
 
@@ -88,11 +91,11 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if len(m.Varkey) > 0 {
 			key, e := m.dataCtx.GetValue(Vars, m.Varkey)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 			wantedKey, e := core.GetWantedValue(key, keyType)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 			return reflect.ValueOf(value).Elem().MapIndex(reflect.ValueOf(wantedKey)).Interface(), nil
 		}
@@ -103,7 +106,7 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 
 		wantedKey, e := core.GetWantedValue(m.Intkey, keyType)
 		if e != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+			return nil, m.codeError(errors.KindType, e)
 		}
 		return reflect.ValueOf(value).Elem().MapIndex(reflect.ValueOf(wantedKey)).Interface(), nil
 	}
@@ -114,7 +117,7 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if len(m.Varkey) > 0 {
 			wantedKey, e := m.dataCtx.GetValue(Vars, m.Varkey)
 			if e != nil {
-				return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", m.LineNum, m.Column, m.Code, e))
+				return nil, m.codeError(errors.KindType, e)
 			}
 			return reflect.ValueOf(value).Elem().Index(int(reflect.ValueOf(wantedKey).Int())).Interface(), nil
 		}
@@ -122,11 +125,11 @@ func (m *MapVar) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		if m.Intkey >= 0 {
 			return reflect.ValueOf(value).Elem().Index(int(m.Intkey)).Interface(), nil
 		} else {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code %s, Slice or Array index must be non-negative!", m.LineNum, m.Column, m.Code))
+			return nil, m.codeError(errors.KindType, errors.New("Slice or Array index must be non-negative!"))
 		}
 	}
 
-	return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, Evaluate MapVarValue Only support directly-Pointer-Map, directly-Pointer-Slice and directly-Pointer-Array  or Map, Slice and Array in Pointer-Struct!", m.LineNum, m.Column, m.Code))
+	return nil, m.codeError(errors.KindType, errors.New("Evaluate MapVarValue Only support directly-Pointer-Map, directly-Pointer-Slice and directly-Pointer-Array  or Map, Slice and Array in Pointer-Struct!"))
 }
 
 func (m *MapVar) AcceptVariable(name string) error {
@@ -1,7 +1,6 @@
 package base
 
 import (
-	"fmt"
 	"gengine/context"
 	"gengine/internal/core"
 	"gengine/internal/core/errors"
@@ -14,6 +13,8 @@ type MathExpression struct {
 	MathMdOperator      string
 	MathExpressionRight *MathExpression
 	ExpressionAtom      *ExpressionAtom
+	Decimal             bool // decimal mode, numbers are calculated as core.Decimal
+	Overflow            int  // what to do when integer arithmetic overflows, see core.OverflowWrap
 	dataCtx             *context.DataContext
 }
 
@@ -74,36 +75,40 @@ func (e *MathExpression) Evaluate(Vars map[string]interface{}) (interface{}, err
 		return nil, err
 	}
 
+	if e.Decimal {
+		lv, rv = core.PromoteDecimal(lv, rv)
+	}
+
 	if e.MathPmOperator == "+" {
-		add, err := core.Add(lv, rv)
+		add, err := core.CheckedAdd(lv, rv, e.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", e.LineNum, e.Column, e.Code, err))
+			return nil, e.codeError(errors.KindType, err)
 		}
 		return add, nil
 	}
 
 	if e.MathPmOperator == "-" {
-		sub, err := core.Sub(lv, rv)
+		sub, err := core.CheckedSub(lv, rv, e.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", e.LineNum, e.Column, e.Code, err))
+			return nil, e.codeError(errors.KindType, err)
 		}
 		return sub, nil
 	}
 
 	if e.MathMdOperator == "*" {
-		mul, err := core.Mul(lv, rv)
+		mul, err := core.CheckedMul(lv, rv, e.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", e.LineNum, e.Column, e.Code, err))
+			return nil, e.codeError(errors.KindType, err)
 		}
 		return mul, nil
 	}
 
 	if e.MathMdOperator == "/" {
-		div, err := core.Div(lv, rv)
+		div, err := core.CheckedDiv(lv, rv, e.Overflow)
 		if err != nil {
-			return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", e.LineNum, e.Column, e.Code, err))
+			return nil, e.codeError(errors.KindType, err)
 		}
 		return div, nil
 	}
-	return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, MathExpression calculate evaluate error", e.LineNum, e.Column, e.Code))
+	return nil, e.codeError(errors.KindType, errors.New("MathExpression calculate evaluate error"))
 }
@@ -1,11 +1,8 @@
 package base
 
 import (
-	"fmt"
 	"gengine/context"
 	"gengine/internal/core/errors"
-	"runtime"
-	"strings"
 )
 
 type MethodCall struct {
@@ -35,16 +32,7 @@ func (mc *MethodCall) Evaluate(Vars map[string]interface{}) (mr interface{}, err
 
 	defer func() {
 		if e := recover(); e != nil {
-			size := 1 << 10 * 10
-			buf := make([]byte, size)
-			rs := runtime.Stack(buf, false)
-			if rs > size {
-				rs = size
-			}
-			buf = buf[:rs]
-			eMsg := fmt.Sprintf("line %d, column %d, code: %s, %+v \n%s", mc.LineNum, mc.Column, mc.Code, e, string(buf))
-			eMsg = strings.ReplaceAll(eMsg,"panic", "error")
-			err = errors.New(eMsg)
+			err = mc.codeError(errors.KindCall, errors.NewPanicError(e))
 		}
 	}()
 
@@ -59,9 +47,14 @@ func (mc *MethodCall) Evaluate(Vars map[string]interface{}) (mr interface{}, err
 		argumentValues = av
 	}
 
-	mr, err = mc.dataCtx.ExecMethod(mc.MethodName, argumentValues)
+	if be := mc.dataCtx.Execution(Vars).CountCall(); be != nil {
+		be.LineNum, be.Column, be.Code = mc.LineNum, mc.Column, mc.Code
+		return nil, be
+	}
+
+	mr, err = mc.dataCtx.ExecMethodWithVars(Vars, mc.MethodName, argumentValues)
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("line %d, column %d, code: %s, %+v", mc.LineNum, mc.Column, mc.Code, err))
+		return nil, mc.codeError(errors.KindCall, err)
 	}
 	return
 }
@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"gengine/context"
 	"gengine/internal/core/errors"
+	"sort"
 )
 
 type RuleEntity struct {
 	RuleName        string
 	Salience        int64
 	RuleDescription string
+	When            *Expression // the condition of the rule, a rule without it is always active
+	NoLoop          bool        // in the forward chaining model, the changes made by the rule do not activate it again
+	After           []string    // in the DAG model, the rule is executed after these rules
+	Group           string      // the group of the rule, see Gengine.ExecuteGroups
+	Order           int         // the order of the rule in the sources, it orders the rules with the same salience
 	RuleContent     *RuleContent
 	dataCtx         *context.DataContext
-	Vars            map[string]interface{} //belongs to current rule,rule execute finish, it will be clear
+	Vars            map[string]interface{} //not used, the variables of the rule belong to every call of Execute, see context.NewVars
 }
 
 func (r *RuleEntity) AcceptString(s string) error {
@@ -33,20 +39,67 @@ func (r *RuleEntity) AcceptInteger(val int64) error {
 	return nil
 }
 
+func (r *RuleEntity) AcceptExpression(expr *Expression) error {
+	if r.When == nil {
+		r.When = expr
+		return nil
+	}
+	return errors.New(fmt.Sprintf("rule \"%s\" when condition set twice!", r.RuleName))
+}
+
 func (r *RuleEntity) Initialize(dc *context.DataContext) {
 	r.dataCtx = dc
 
+	if r.When != nil {
+		r.When.Initialize(dc)
+	}
+
 	if r.RuleContent != nil {
 		r.RuleContent.Initialize(dc)
 	}
 }
 
-func (r *RuleEntity) Execute() error {
-	r.Vars = make(map[string]interface{})
-	defer r.clearMap()
-	return r.RuleContent.Execute(r.Vars)
+// evaluate the when condition of the rule in the execution, the rule should be executed only when it is true
+func (r *RuleEntity) IsActive(exec *context.Execution) (bool, error) {
+	if r.When == nil {
+		return true, nil
+	}
+
+	v, err := evaluateCondition("when", r.When, context.NewVars(exec))
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, r.When.codeError(errors.KindType, errors.New("the when condition is not a bool"))
+	}
+	return b, nil
+}
+
+/**
+the facts read by the when condition: the variables, map vars and the objects whose methods are called,
+such as "Order.Total", "Scores" or "Order"
+*/
+func (r *RuleEntity) Facts() []string {
+	if r.When == nil {
+		return nil
+	}
+	facts := make(map[string]bool)
+	r.When.collectFacts(facts)
+
+	var names []string
+	for name := range facts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-func (r *RuleEntity) clearMap() {
-	r.Vars = make(map[string]interface{})
+// execute the rule in the execution, the variables of the rule belong to the call, so a rule can be executed by many goroutines
+func (r *RuleEntity) Execute(exec *context.Execution) error {
+	err := r.RuleContent.Execute(context.NewVars(exec))
+	if err == errExit {
+		return nil
+	}
+	return err
 }
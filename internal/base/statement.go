@@ -5,16 +5,33 @@ import (
 	"gengine/internal/core/errors"
 )
 
+// returned by the halt statement, the rule stops and no rule is executed after it, it is not an error of the rule
+var ErrHalt = errors.New("halt")
+
+// returned by the exit statement, the rule stops, it is not an error of the rule
+var errExit = errors.New("exit")
+
 type Statement struct {
 	IfStmt        *IfStmt
 	MethodCall    *MethodCall
 	FunctionCall  *FunctionCall
 	Assignment    *Assignment
 	ConcStatement *ConcStatement
+	Halt          bool
+	Exit          bool
+	Rollback      bool
 	dataCtx       *context.DataContext
+	SourceCode
 }
 
-func (s *Statement) Evaluate(Vars map[string]interface{}) (interface{}, error) {
+func (s *Statement) Evaluate(Vars map[string]interface{}) (res interface{}, err error) {
+
+	// a panic in the code without its own recover, such as a condition or a conversion, is the error of the statement
+	defer func() {
+		if e := recover(); e != nil {
+			err = s.panicError(e)
+		}
+	}()
 
 	if s.IfStmt != nil {
 		return s.IfStmt.Evaluate(Vars)
@@ -36,6 +53,21 @@ func (s *Statement) Evaluate(Vars map[string]interface{}) (interface{}, error) {
 		return s.ConcStatement.Evaluate(Vars)
 	}
 
+	if s.Halt {
+		return nil, ErrHalt
+	}
+
+	if s.Exit {
+		return nil, errExit
+	}
+
+	if s.Rollback {
+		if !s.dataCtx.Execution(Vars).InTransaction() {
+			return nil, errors.New("rollback is only supported in the transactional mode")
+		}
+		return nil, errors.ErrRollback
+	}
+
 	return nil, errors.New("Statement evaluate error!")
 }
 
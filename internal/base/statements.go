@@ -2,6 +2,7 @@ package base
 
 import (
 	"gengine/context"
+	"gengine/internal/core/errors"
 )
 
 type Statements struct {
@@ -10,10 +11,29 @@ type Statements struct {
 }
 
 func (s *Statements) Evaluate(Vars map[string]interface{}) (interface{}, error) {
+	exec := s.dataCtx.Execution(Vars)
 	for _, statement := range s.StatementList {
+		//abort between statements when the execution is cancelled
+		if exec.Ctx != nil {
+			if err := exec.Ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if exec.Budget != nil {
+			if err := exec.Budget.Statement(); err != nil {
+				err.LineNum, err.Column, err.Code = statement.LineNum, statement.Column, statement.Code
+				return nil, err
+			}
+		}
 		_, err := statement.Evaluate(Vars)
 		if err != nil {
-			return nil, err
+			if err == ErrHalt || err == errExit {
+				return nil, err
+			}
+			if err == errors.ErrRollback {
+				return nil, statement.codeError(errors.KindRollback, err)
+			}
+			return nil, statement.codeError(errors.KindType, err)
 		}
 	}
 	return nil, nil
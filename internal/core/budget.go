@@ -0,0 +1,56 @@
+package core
+
+import (
+	"gengine/internal/core/errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// the limits of one execution, zero means no limit
+type Limits struct {
+	MaxStatements int64
+	MaxCalls      int64
+	MaxDuration   time.Duration
+}
+
+func (l Limits) IsZero() bool {
+	return l.MaxStatements <= 0 && l.MaxCalls <= 0 && l.MaxDuration <= 0
+}
+
+// the counters of one execution, it is shared by the concurrent rules
+type Budget struct {
+	limits     Limits
+	start      time.Time
+	statements int64
+	calls      int64
+}
+
+func NewBudget(limits Limits) *Budget {
+	return &Budget{limits: limits, start: time.Now()}
+}
+
+// count one evaluated statement, the position of the error is filled by the caller
+func (b *Budget) Statement() *errors.BudgetError {
+	n := atomic.AddInt64(&b.statements, 1)
+	if b.limits.MaxStatements > 0 && n > b.limits.MaxStatements {
+		return &errors.BudgetError{Kind: errors.BudgetStatements, Limit: strconv.FormatInt(b.limits.MaxStatements, 10)}
+	}
+	return b.checkDuration()
+}
+
+// count one function or method call, the position of the error is filled by the caller
+func (b *Budget) Call() *errors.BudgetError {
+	n := atomic.AddInt64(&b.calls, 1)
+	if b.limits.MaxCalls > 0 && n > b.limits.MaxCalls {
+		return &errors.BudgetError{Kind: errors.BudgetCalls, Limit: strconv.FormatInt(b.limits.MaxCalls, 10)}
+	}
+	return b.checkDuration()
+}
+
+func (b *Budget) checkDuration() *errors.BudgetError {
+	if b.limits.MaxDuration > 0 && time.Since(b.start) > b.limits.MaxDuration {
+		return &errors.BudgetError{Kind: errors.BudgetDuration, Limit: b.limits.MaxDuration.String()}
+	}
+	return nil
+}
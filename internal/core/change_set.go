@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+/**
+the writes intended by one dry run execution, in the order the rules made them, it can be serialized to json
+
+the rules of the execution read the intended values instead of the ones of the injected objects,
+so they behave as if the writes were applied
+*/
+type ChangeSet struct {
+	lock    sync.Mutex
+	Changes []*Change `json:"changes"`
+	values  map[string]interface{}
+}
+
+/**
+an intended write: Path is the target such as "Order.Total", "Total" or "Scores[\"a\"]",
+Old is the value before the write and New is the value to write
+
+for a call of a mutating method or function, Path is its name such as "Order.Cancel", Call is true,
+Old is nil and New is the arguments
+*/
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+	Call bool        `json:"call,omitempty"`
+}
+
+func NewChangeSet() *ChangeSet {
+	return &ChangeSet{values: make(map[string]interface{})}
+}
+
+func (cs *ChangeSet) Set(path string, old, new interface{}) {
+	cs.lock.Lock()
+	cs.Changes = append(cs.Changes, &Change{Path: path, Old: old, New: new})
+	cs.values[path] = new
+	cs.lock.Unlock()
+}
+
+func (cs *ChangeSet) Call(name string, args []interface{}) {
+	cs.lock.Lock()
+	cs.Changes = append(cs.Changes, &Change{Path: name, New: args, Call: true})
+	cs.lock.Unlock()
+}
+
+// the last intended value of the path, ok is false when nothing is written to it
+func (cs *ChangeSet) Get(path string) (value interface{}, ok bool) {
+	cs.lock.Lock()
+	value, ok = cs.values[path]
+	cs.lock.Unlock()
+	return
+}
+
+// the path of an element of a map, slice or array, the string keys are quoted, such as Scores["a"] or Items[1]
+func MapVarPath(name string, key interface{}) string {
+	if s, ok := key.(string); ok {
+		return fmt.Sprintf("%s[%q]", name, s)
+	}
+	return fmt.Sprintf("%s[%v]", name, key)
+}
+
+// the values returned by a call which is not made, the zero values of the results of fun
+func ZeroResults(fun reflect.Value) []reflect.Value {
+	t := fun.Type()
+	rs := make([]reflect.Value, t.NumOut())
+	for i := range rs {
+		rs[i] = reflect.Zero(t.Out(i))
+	}
+	return rs
+}
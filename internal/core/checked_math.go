@@ -0,0 +1,148 @@
+package core
+
+import (
+	"fmt"
+	"gengine/internal/core/errors"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// what to do when integer arithmetic overflows
+const (
+	OverflowWrap     = 0 // golang behaviour, silently wrap around
+	OverflowError    = 1 // return an error
+	OverflowSaturate = 2 // clamp to the min or max value of the result type
+)
+
+var (
+	maxInt64  = big.NewInt(math.MaxInt64)
+	minInt64  = big.NewInt(math.MinInt64)
+	maxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+func CheckedAdd(ax, bx interface{}, mode int) (interface{}, error) {
+	if mode == OverflowWrap {
+		return Add(ax, bx)
+	}
+	return checkedOperation("ADD(+)", ax, bx, mode)
+}
+
+func CheckedSub(ax, bx interface{}, mode int) (interface{}, error) {
+	if mode == OverflowWrap {
+		return Sub(ax, bx)
+	}
+	return checkedOperation("SUB(-)", ax, bx, mode)
+}
+
+func CheckedMul(ax, bx interface{}, mode int) (interface{}, error) {
+	if mode == OverflowWrap {
+		return Mul(ax, bx)
+	}
+	return checkedOperation("MUL(*)", ax, bx, mode)
+}
+
+func CheckedDiv(ax, bx interface{}, mode int) (interface{}, error) {
+	if mode == OverflowWrap {
+		return Div(ax, bx)
+	}
+	return checkedOperation("DIV(/)", ax, bx, mode)
+}
+
+func integerKind(k reflect.Kind) (isInt bool, isUint bool) {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true, false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return false, true
+	}
+	return false, false
+}
+
+func toBigInt(v reflect.Value, isInt bool) *big.Int {
+	if isInt {
+		return big.NewInt(v.Int())
+	}
+	return new(big.Int).SetUint64(v.Uint())
+}
+
+// integer arithmetic is calculated exactly, then checked against the range of the result type,
+// the result type is the same as the unchecked one: uint64 when both are uint, else int64
+func checkedOperation(op string, ax, bx interface{}, mode int) (interface{}, error) {
+	if IsDecimal(ax) || IsDecimal(bx) {
+		return decimalOperation(op, ax, bx)
+	}
+
+	a := reflect.ValueOf(ax)
+	b := reflect.ValueOf(bx)
+	aInt, aUint := integerKind(a.Kind())
+	bInt, bUint := integerKind(b.Kind())
+
+	if !(aInt || aUint) || !(bInt || bUint) {
+		// float or string
+		var res interface{}
+		var e error
+		switch op {
+		case "ADD(+)":
+			res, e = Add(ax, bx)
+		case "SUB(-)":
+			res, e = Sub(ax, bx)
+		case "MUL(*)":
+			res, e = Mul(ax, bx)
+		default:
+			res, e = Div(ax, bx)
+		}
+		if e != nil {
+			return nil, e
+		}
+
+		if f, ok := res.(float64); ok && math.IsInf(f, 0) {
+			if mode == OverflowError {
+				return nil, errors.New(fmt.Sprintf("%s overflows float64: %v and %v", op, ax, bx))
+			}
+			if f > 0 {
+				return math.MaxFloat64, nil
+			}
+			return -math.MaxFloat64, nil
+		}
+		return res, nil
+	}
+
+	x := toBigInt(a, aInt)
+	y := toBigInt(b, bInt)
+	r := new(big.Int)
+	switch op {
+	case "ADD(+)":
+		r.Add(x, y)
+	case "SUB(-)":
+		r.Sub(x, y)
+	case "MUL(*)":
+		r.Mul(x, y)
+	case "DIV(/)":
+		if y.Sign() == 0 {
+			return nil, errors.New("DIV(/) can't be used to Div ZERO(0)!")
+		}
+		r.Quo(x, y)
+	}
+
+	min, max, typeName := minInt64, maxInt64, "int64"
+	if aUint && bUint {
+		min, max, typeName = new(big.Int), maxUint64, "uint64"
+	}
+
+	if r.Cmp(min) < 0 || r.Cmp(max) > 0 {
+		if mode == OverflowError {
+			return nil, errors.New(fmt.Sprintf("%s overflows %s: %v and %v", op, typeName, ax, bx))
+		}
+		if r.Cmp(min) < 0 {
+			r = min
+		} else {
+			r = max
+		}
+	}
+
+	if typeName == "uint64" {
+		return r.Uint64(), nil
+	}
+	return r.Int64(), nil
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"gengine/internal/core/errors"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+/**
+compare two numbers without losing precision:
+int and int, uint and uint, int and uint are compared as integers,
+only when a float is involved, the integer is promoted to an exact big float
+
+return -1 if a < b, 0 if a == b, 1 if a > b
+ok is false when a NaN is involved, which means the two numbers are not comparable
+*/
+func CompareNumber(ax, bx interface{}) (cmp int, ok bool, err error) {
+	a := reflect.ValueOf(ax)
+	b := reflect.ValueOf(bx)
+	aInt, aUint := integerKind(a.Kind())
+	bInt, bUint := integerKind(b.Kind())
+	aFloat := a.Kind() == reflect.Float32 || a.Kind() == reflect.Float64
+	bFloat := b.Kind() == reflect.Float32 || b.Kind() == reflect.Float64
+
+	if !(aInt || aUint || aFloat) || !(bInt || bUint || bFloat) {
+		return 0, false, errors.New(fmt.Sprintf("can't compare %s with %s", a.Kind().String(), b.Kind().String()))
+	}
+
+	switch {
+	case aInt && bInt:
+		return compareInt64(a.Int(), b.Int()), true, nil
+	case aUint && bUint:
+		return compareUint64(a.Uint(), b.Uint()), true, nil
+	case aInt && bUint:
+		if a.Int() < 0 {
+			return -1, true, nil
+		}
+		return compareUint64(uint64(a.Int()), b.Uint()), true, nil
+	case aUint && bInt:
+		if b.Int() < 0 {
+			return 1, true, nil
+		}
+		return compareUint64(a.Uint(), uint64(b.Int())), true, nil
+	case aFloat && bFloat:
+		af, bf := a.Float(), b.Float()
+		if math.IsNaN(af) || math.IsNaN(bf) {
+			return 0, false, nil
+		}
+		if af < bf {
+			return -1, true, nil
+		}
+		if af > bf {
+			return 1, true, nil
+		}
+		return 0, true, nil
+	}
+
+	// float and integer
+	af, aok := exactFloat(a, aInt, aUint)
+	bf, bok := exactFloat(b, bInt, bUint)
+	if !aok || !bok {
+		return 0, false, nil
+	}
+	return af.Cmp(bf), true, nil
+}
+
+func compareInt64(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(a, b uint64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+func exactFloat(v reflect.Value, isInt, isUint bool) (*big.Float, bool) {
+	if isInt {
+		return new(big.Float).SetInt64(v.Int()), true
+	}
+	if isUint {
+		return new(big.Float).SetUint64(v.Uint()), true
+	}
+	f := v.Float()
+	if math.IsNaN(f) {
+		return nil, false
+	}
+	return new(big.Float).SetFloat64(f), true
+}
+
+/**
+compare time.Time with time.Time, or time.Duration with time.Duration or integer
+ok is false when they are not time values
+*/
+func CompareTime(ax, bx interface{}) (cmp int, ok bool) {
+	if at, aok := ax.(time.Time); aok {
+		bt, bok := bx.(time.Time)
+		if !bok {
+			return 0, false
+		}
+		if at.Before(bt) {
+			return -1, true
+		}
+		if at.After(bt) {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	_, aDuration := ax.(time.Duration)
+	_, bDuration := bx.(time.Duration)
+	if aDuration || bDuration {
+		aInt, _ := integerKind(reflect.ValueOf(ax).Kind())
+		bInt, _ := integerKind(reflect.ValueOf(bx).Kind())
+		if aInt && bInt {
+			return compareInt64(reflect.ValueOf(ax).Int(), reflect.ValueOf(bx).Int()), true
+		}
+	}
+	return 0, false
+}
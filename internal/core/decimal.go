@@ -0,0 +1,420 @@
+package core
+
+import (
+	"fmt"
+	"gengine/internal/core/errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// the number of digits kept after the decimal point when a division does not terminate
+const DivisionPrecision = 16
+
+// the max absolute exponent of a decimal string, a bigger one would take too much time and memory to expand
+const MaxDecimalExponent = 10000
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// Decimal is an arbitrary-precision decimal number: value * 10^(-scale)
+// the zero value is 0, and all operations return new values
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+func NewDecimalFromInt(i int64) Decimal {
+	return Decimal{value: big.NewInt(i)}
+}
+
+func NewDecimalFromUint(u uint64) Decimal {
+	return Decimal{value: new(big.Int).SetUint64(u)}
+}
+
+// use the shortest representation of the float, so 0.1 becomes exactly 0.1
+func NewDecimalFromFloat(f float64) (Decimal, error) {
+	return NewDecimalFromString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// support "123", "-1.25", ".5", "1e-3", "1.2E+4", the exponent is limited by MaxDecimalExponent
+func NewDecimalFromString(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, errors.New(fmt.Sprintf("can't convert \"%s\" to decimal", s))
+		}
+		if e > MaxDecimalExponent || e < -MaxDecimalExponent {
+			return Decimal{}, errors.New(fmt.Sprintf("can't convert \"%s\" to decimal, the exponent is out of [-%d, %d]", s, MaxDecimalExponent, MaxDecimalExponent))
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	scale := int64(0)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		scale = int64(len(str) - i - 1)
+		str = str[:i] + str[i+1:]
+	}
+
+	if str == "" || str == "-" || str == "+" {
+		return Decimal{}, errors.New(fmt.Sprintf("can't convert \"%s\" to decimal", s))
+	}
+
+	value, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return Decimal{}, errors.New(fmt.Sprintf("can't convert \"%s\" to decimal", s))
+	}
+
+	scale -= exp
+	if scale < 0 {
+		value.Mul(value, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+func IsDecimal(v interface{}) bool {
+	_, ok := v.(Decimal)
+	return ok
+}
+
+// convert a go number (or Decimal) to Decimal
+func ToDecimal(v interface{}) (Decimal, error) {
+	if d, ok := v.(Decimal); ok {
+		return d, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewDecimalFromInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewDecimalFromUint(rv.Uint()), nil
+	case reflect.Float32:
+		return NewDecimalFromString(strconv.FormatFloat(rv.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		return NewDecimalFromFloat(rv.Float())
+	case reflect.String:
+		return NewDecimalFromString(rv.String())
+	}
+	return Decimal{}, errors.New(fmt.Sprintf("can't convert %s to decimal", rv.Kind().String()))
+}
+
+// in decimal mode, numbers on both side of an operator are promoted to Decimal, others stay unchanged
+func PromoteDecimal(ax, bx interface{}) (interface{}, interface{}) {
+	if isNumber(ax) && isNumber(bx) {
+		a, ea := ToDecimal(ax)
+		b, eb := ToDecimal(bx)
+		if ea == nil && eb == nil {
+			return a, b
+		}
+	}
+	return ax, bx
+}
+
+func isNumber(v interface{}) bool {
+	if IsDecimal(v) {
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescale to a bigger scale without losing precision
+func (d Decimal) rescale(scale int32) *big.Int {
+	v := new(big.Int).Set(d.unscaled())
+	if scale > d.scale {
+		v.Mul(v, pow10(int64(scale-d.scale)))
+	}
+	return v
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{value: a.Add(a, b), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{value: a.Sub(a, b), scale: scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	v := new(big.Int).Mul(d.unscaled(), o.unscaled())
+	return Decimal{value: v, scale: d.scale + o.scale}
+}
+
+// exact when the quotient terminates, else rounded half away from zero to DivisionPrecision digits
+func (d Decimal) Div(o Decimal) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, errors.New("DIV(/) can't be used to Div ZERO(0)!")
+	}
+
+	// d / o = (dv * 10^(p + os - ds)) / ov * 10^(-p)
+	p := int64(DivisionPrecision)
+	if s := int64(d.scale); s > p {
+		p = s
+	}
+	num := new(big.Int).Mul(d.unscaled(), pow10(p+1+int64(o.scale)-int64(d.scale)))
+	q := new(big.Int).Quo(num, o.unscaled())
+	return Decimal{value: q, scale: int32(p + 1)}.Round(int32(p)).normalize(), nil
+}
+
+// remove the trailing zeros after the decimal point
+func (d Decimal) normalize() Decimal {
+	v := new(big.Int).Set(d.unscaled())
+	scale := d.scale
+	r := new(big.Int)
+	for scale > 0 {
+		q, m := new(big.Int).QuoRem(v, bigTen, r)
+		if m.Sign() != 0 {
+			break
+		}
+		v = q
+		scale--
+	}
+	return Decimal{value: v, scale: scale}
+}
+
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// -1 if d < o, 0 if d == o, 1 if d > o
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+const (
+	roundHalfUp = iota
+	roundHalfEven
+	roundDown
+	roundFloor
+	roundCeil
+)
+
+func (d Decimal) round(places int32, mode int) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	div := pow10(int64(d.scale - places))
+	q, r := new(big.Int).QuoRem(d.unscaled(), div, new(big.Int))
+	if r.Sign() != 0 {
+		// compare |2r| with div to decide the half
+		half := new(big.Int).Abs(r)
+		half.Mul(half, big.NewInt(2))
+		c := half.Cmp(div)
+
+		up := false
+		switch mode {
+		case roundHalfUp:
+			up = c >= 0
+		case roundHalfEven:
+			up = c > 0 || (c == 0 && q.Bit(0) == 1)
+		case roundFloor:
+			up = r.Sign() < 0
+		case roundCeil:
+			up = r.Sign() > 0
+		}
+
+		if up {
+			if r.Sign() < 0 {
+				q.Sub(q, bigOne)
+			} else {
+				q.Add(q, bigOne)
+			}
+		}
+	}
+
+	if places < 0 {
+		q.Mul(q, pow10(int64(-places)))
+		places = 0
+	}
+	return Decimal{value: q, scale: places}
+}
+
+// round half away from zero, 2.345 -> 2.35, -2.345 -> -2.35
+func (d Decimal) Round(places int32) Decimal {
+	return d.round(places, roundHalfUp)
+}
+
+// round half to even (banker's rounding), 2.345 -> 2.34, 2.355 -> 2.36
+func (d Decimal) RoundBank(places int32) Decimal {
+	return d.round(places, roundHalfEven)
+}
+
+// cut the digits toward zero
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.round(places, roundDown)
+}
+
+// round toward negative infinity
+func (d Decimal) Floor(places int32) Decimal {
+	return d.round(places, roundFloor)
+}
+
+// round toward positive infinity
+func (d Decimal) Ceil(places int32) Decimal {
+	return d.round(places, roundCeil)
+}
+
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.unscaled()).String()
+	if d.scale > 0 {
+		if len(s) <= int(d.scale) {
+			s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// the nearest float64, an error is returned when it is out of the range of float64
+func (d Decimal) Float64() (float64, error) {
+	f, err := strconv.ParseFloat(d.String(), 64)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("decimal %s overflows float64", d.String()))
+	}
+	return f, nil
+}
+
+// the fraction is truncated, just like golang float -> int
+func (d Decimal) Int64() int64 {
+	return d.Truncate(0).unscaled().Int64()
+}
+
+func (d Decimal) Uint64() uint64 {
+	return d.Truncate(0).unscaled().Uint64()
+}
+
+// the sizes of the integer kinds
+var intBits = map[reflect.Kind]uint{
+	reflect.Int:    strconv.IntSize,
+	reflect.Int8:   8,
+	reflect.Int16:  16,
+	reflect.Int32:  32,
+	reflect.Int64:  64,
+	reflect.Uint:   strconv.IntSize,
+	reflect.Uint8:  8,
+	reflect.Uint16: 16,
+	reflect.Uint32: 32,
+	reflect.Uint64: 64,
+}
+
+// convert a Decimal to the wanted number kind, other kinds keep the Decimal,
+// the fraction is truncated for the integer kinds, and an error is returned when it is out of the range of the kind
+func DecimalToKind(d Decimal, kind reflect.Kind) (interface{}, error) {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := d.Truncate(0).unscaled()
+		min := int64(-1) << (intBits[kind] - 1)
+		if !i.IsInt64() || i.Int64() < min || i.Int64() > -(min+1) {
+			return nil, errors.New(fmt.Sprintf("decimal %s overflows %s", d.String(), kind.String()))
+		}
+		return i.Int64(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := d.Truncate(0).unscaled()
+		if !u.IsUint64() || u.Uint64() > ^uint64(0)>>(64-intBits[kind]) {
+			return nil, errors.New(fmt.Sprintf("decimal %s overflows %s", d.String(), kind.String()))
+		}
+		return u.Uint64(), nil
+	case reflect.Float32, reflect.Float64:
+		f, err := d.Float64()
+		if err == nil && kind == reflect.Float32 && math.Abs(f) > math.MaxFloat32 {
+			err = errors.New(fmt.Sprintf("decimal %s overflows float32", d.String()))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	case reflect.String:
+		return d.String(), nil
+	}
+	return d, nil
+}
+
+func decimalOperation(op string, ax, bx interface{}) (interface{}, error) {
+	if !isNumber(ax) || !isNumber(bx) {
+		return nil, errors.New(fmt.Sprintf("%s can't be used between %s and %s", op, reflect.ValueOf(ax).Kind().String(), reflect.ValueOf(bx).Kind().String()))
+	}
+	a, e := ToDecimal(ax)
+	if e != nil {
+		return nil, e
+	}
+	b, e := ToDecimal(bx)
+	if e != nil {
+		return nil, e
+	}
+
+	switch op {
+	case "ADD(+)":
+		return a.Add(b), nil
+	case "SUB(-)":
+		return a.Sub(b), nil
+	case "MUL(*)":
+		return a.Mul(b), nil
+	case "DIV(/)":
+		return a.Div(b)
+	}
+	return nil, errors.New(fmt.Sprintf("not supported decimal operation: %s", op))
+}
+
+// compare two numbers when one of them is Decimal
+func CompareDecimal(ax, bx interface{}) (int, error) {
+	if !isNumber(ax) || !isNumber(bx) {
+		return 0, errors.New(fmt.Sprintf("can't compare %s with %s", reflect.ValueOf(ax).Kind().String(), reflect.ValueOf(bx).Kind().String()))
+	}
+	a, e := ToDecimal(ax)
+	if e != nil {
+		return 0, e
+	}
+	b, e := ToDecimal(bx)
+	if e != nil {
+		return 0, e
+	}
+	return a.Cmp(b), nil
+}
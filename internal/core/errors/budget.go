@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// every BudgetError is ErrBudgetExceeded, use errors.Is(err, ErrBudgetExceeded) to check it
+var ErrBudgetExceeded = errors.New("execution budget exceeded")
+
+// which limit is exceeded
+const (
+	BudgetStatements = "statements"
+	BudgetCalls      = "calls"
+	BudgetDuration   = "duration"
+	BudgetCycles     = "cycles" // the rule firings of the forward chaining model
+)
+
+/**
+the error returned when an execution exceeds one of its limits,
+it identifies the rule and the source position where the limit was reached
+*/
+type BudgetError struct {
+	Kind     string
+	Limit    string
+	RuleName string
+	LineNum  int
+	Column   int
+	Code     string
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("rule: \"%s\", line %d, column %d, code: %s, %s: more than %s %s", e.RuleName, e.LineNum, e.Column, e.Code, ErrBudgetExceeded.Error(), e.Limit, e.Kind)
+}
+
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
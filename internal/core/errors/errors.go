@@ -7,3 +7,11 @@ import (
 func New(text string) error {
 	return errors.New(text)
 }
+
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
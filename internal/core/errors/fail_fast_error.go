@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"fmt"
+)
+
+/**
+returned by a concurrent step with fail fast, when a rule returns an error the other rules of the step are cancelled,
+Err is the first error, errors.Is and errors.As work on it
+*/
+type FailFastError struct {
+	Err       error
+	Cancelled []string // the rules which were aborted at a statement or were not started
+}
+
+func (e *FailFastError) Error() string {
+	return fmt.Sprintf("%v, cancelled rules: %+v", e.Err, e.Cancelled)
+}
+
+func (e *FailFastError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// a recovered panic with the stack of the goroutine where it occurred
+type PanicError struct {
+	Value interface{}
+	Stack string
+}
+
+// call it in the deferred function which recovers the panic, so the stack is the one of the panic
+func NewPanicError(v interface{}) *PanicError {
+	size := 1 << 10 * 10
+	buf := make([]byte, size)
+	rs := runtime.Stack(buf, false)
+	if rs > size {
+		rs = size
+	}
+	return &PanicError{Value: v, Stack: string(buf[:rs])}
+}
+
+// the value and the stack are kept as they are, only the prefix tells it is recovered
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered error: %+v\n%s", e.Value, e.Stack)
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// the kinds of RuleError
+const (
+	KindParse     = "parse"     // the rules can not be built
+	KindType      = "type"      // a value or an operator can not be used in the code
+	KindCall      = "call"      // a function or method call returns an error or panics, the cause of a panic is a *PanicError
+	KindBudget    = "budget"    // the execution exceeds one of its limits, the cause is a *BudgetError
+	KindCancelled = "cancelled" // the context of the execution is done, the cause is ctx.Err()
+	KindPanic     = "panic"     // the code panics outside of a call, the cause is a *PanicError
+	KindRollback  = "rollback"  // the rule executes rollback in the transactional mode, the cause is ErrRollback
+	KindSelect    = "select"    // no rule is injected, or the selected rules or groups do not exist
+)
+
+// returned by the rollback statement, use errors.Is(err, ErrRollback) to check it
+var ErrRollback = errors.New("the execution is rolled back")
+
+/**
+the error of a rule, it has the position of the code where the error occurs when it is known,
+the cause is the original error, errors.Is and errors.As work on it
+*/
+type RuleError struct {
+	RuleName string
+	LineNum  int
+	Column   int
+	Code     string
+	Kind     string
+	Cause    error
+}
+
+func (e *RuleError) Error() string {
+	switch e.Kind {
+	case KindParse:
+		if e.LineNum == 0 {
+			return e.Cause.Error()
+		}
+		return fmt.Sprintf("line %d:%d %v", e.LineNum, e.Column, e.Cause)
+	case KindBudget, KindSelect:
+		return e.Cause.Error()
+	case KindCancelled:
+		return fmt.Sprintf("rule: \"%s\" cancelled, error: %v", e.RuleName, e.Cause)
+	case KindRollback:
+		return fmt.Sprintf("rule: \"%s\" line %d, column %d: %v", e.RuleName, e.LineNum, e.Column, e.Cause)
+	}
+
+	if e.Code == "" {
+		return fmt.Sprintf("rule: \"%s\" executed, error:\n %+v ", e.RuleName, e.Cause)
+	}
+	return fmt.Sprintf("rule: \"%s\" executed, error:\n line %d, column %d, code: %s, %+v ", e.RuleName, e.LineNum, e.Column, e.Code, e.Cause)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Cause
+}
+
+/**
+the errors of one build or one execution, errors.Is and errors.As report whether one of them matches,
+such as errors.Is(err, context.Canceled) or errors.As(err, &ruleErr)
+*/
+type MultiError struct {
+	Errors []error
+}
+
+func (m *MultiError) Error() string {
+	msgs := make([]string, len(m.Errors))
+	for i, e := range m.Errors {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%+v", msgs)
+}
+
+func (m *MultiError) Is(target error) bool {
+	for _, e := range m.Errors {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MultiError) As(target interface{}) bool {
+	for _, e := range m.Errors {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -18,7 +18,10 @@ func InvokeFunction(obj interface{}, methodName string, parameters []interface{}
 		fun = f.Interface()
 	}
 	//change type for base type params
-	params := ParamsTypeChange(fun, parameters)
+	params, e := ParamsTypeChange(fun, parameters)
+	if e != nil {
+		return nil, e
+	}
 	args := make([]reflect.Value, 0)
 	for _, param := range params {
 		args = append(args, reflect.ValueOf(param))
@@ -179,6 +182,13 @@ func SetAttributeValue(obj interface{}, fieldName string, value interface{}) err
 	}
 
 	if field.CanSet() {
+		if d, ok := value.(Decimal); ok {
+			v, e := DecimalToKind(d, field.Type().Kind())
+			if e != nil {
+				return e
+			}
+			value = v
+		}
 		typeName := reflect.ValueOf(value).Type().String()
 		switch field.Type().Kind() {
 		case reflect.String:
@@ -257,13 +267,20 @@ const (
 /*
 number type exchange
 */
-func ParamsTypeChange(f interface{}, params []interface{}) []interface{} {
+func ParamsTypeChange(f interface{}, params []interface{}) ([]interface{}, error) {
 	tf := reflect.TypeOf(f)
 	if tf.Kind() == reflect.Ptr {
 		tf = tf.Elem()
 	}
 	plen := tf.NumIn()
 	for i := 0; i < plen; i++ {
+		if d, ok := params[i].(Decimal); ok {
+			v, e := DecimalToKind(d, tf.In(i).Kind())
+			if e != nil {
+				return nil, e
+			}
+			params[i] = v
+		}
 		switch tf.In(i).Kind() {
 		case reflect.Int:
 			tag := getNumType(params[i])
@@ -395,7 +412,7 @@ func ParamsTypeChange(f interface{}, params []interface{}) []interface{} {
 			continue
 		}
 	}
-	return params
+	return params, nil
 }
 
 func getNumType(param interface{}) int {
@@ -415,7 +432,32 @@ func getNumType(param interface{}) int {
 	panic(fmt.Sprintf("it is not number type, type is %s !", ts))
 }
 
+var numberKinds = map[string]reflect.Kind{
+	"int":     reflect.Int,
+	"int8":    reflect.Int8,
+	"int16":   reflect.Int16,
+	"int32":   reflect.Int32,
+	"int64":   reflect.Int64,
+	"uint":    reflect.Uint,
+	"uint8":   reflect.Uint8,
+	"uint16":  reflect.Uint16,
+	"uint32":  reflect.Uint32,
+	"uint64":  reflect.Uint64,
+	"float32": reflect.Float32,
+	"float64": reflect.Float64,
+	"string":  reflect.String,
+}
+
 func GetWantedValue(newValue interface{}, toKind string) (interface{}, error) {
+	if d, ok := newValue.(Decimal); ok {
+		if kind, ok := numberKinds[toKind]; ok {
+			v, e := DecimalToKind(d, kind)
+			if e != nil {
+				return nil, e
+			}
+			newValue = v
+		}
+	}
 	rawKind := reflect.ValueOf(newValue).Kind().String()
 	if rawKind == toKind {
 		return newValue, nil
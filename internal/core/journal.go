@@ -0,0 +1,32 @@
+package core
+
+import "sync"
+
+/**
+the writes made by one transactional execution to the injected objects,
+each of them is kept as the function which restores the value before it
+*/
+type Journal struct {
+	lock  sync.Mutex
+	undos []func()
+}
+
+func NewJournal() *Journal {
+	return &Journal{}
+}
+
+func (j *Journal) Record(undo func()) {
+	j.lock.Lock()
+	j.undos = append(j.undos, undo)
+	j.lock.Unlock()
+}
+
+// restore the values before the writes in the reverse order, the journal is empty after it
+func (j *Journal) Rollback() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for i := len(j.undos) - 1; i >= 0; i-- {
+		j.undos[i]()
+	}
+	j.undos = nil
+}
@@ -8,6 +8,10 @@ import (
 )
 
 func Add(ax, bx interface{}) (interface{}, error) {
+	if IsDecimal(ax) || IsDecimal(bx) {
+		return decimalOperation("ADD(+)", ax, bx)
+	}
+
 	a := reflect.ValueOf(ax)
 	akind := a.Kind().String()
 	b := reflect.ValueOf(bx)
@@ -73,6 +77,10 @@ func Add(ax, bx interface{}) (interface{}, error) {
 }
 
 func Sub(ax, bx interface{}) (interface{}, error) {
+	if IsDecimal(ax) || IsDecimal(bx) {
+		return decimalOperation("SUB(-)", ax, bx)
+	}
+
 	a := reflect.ValueOf(ax)
 	b := reflect.ValueOf(bx)
 	akind := a.Kind().String()
@@ -127,6 +135,10 @@ func Sub(ax, bx interface{}) (interface{}, error) {
 }
 
 func Mul(ax, bx interface{}) (interface{}, error) {
+	if IsDecimal(ax) || IsDecimal(bx) {
+		return decimalOperation("MUL(*)", ax, bx)
+	}
+
 	a := reflect.ValueOf(ax)
 	b := reflect.ValueOf(bx)
 	akind := a.Kind().String()
@@ -181,6 +193,10 @@ func Mul(ax, bx interface{}) (interface{}, error) {
 }
 
 func Div(ax, bx interface{}) (interface{}, error) {
+	if IsDecimal(ax) || IsDecimal(bx) {
+		return decimalOperation("DIV(/)", ax, bx)
+	}
+
 	a := reflect.ValueOf(ax)
 	b := reflect.ValueOf(bx)
 	akind := a.Kind().String()
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/**
+run the tasks 0..count-1 concurrently and wait for them, with at most max goroutines,
+every goroutine is a worker which runs the next task until all of them have been taken,
+max <= 0 means one goroutine per task, the goroutines are not reused by the next call, see Workers for that
+
+when a task returns false, the tasks which have not been taken are not run,
+the tasks must recover their own panics
+*/
+func Parallel(max int, count int, task func(i int) bool) {
+	if count <= 0 {
+		return
+	}
+	workers := count
+	if max > 0 && max < count {
+		workers = max
+	}
+
+	var next int64 = -1
+	var stopped int32
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&stopped) == 0 {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= count {
+					return
+				}
+				if !task(i) {
+					atomic.StoreInt32(&stopped, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// a goroutine of Workers exits after it is idle for this time
+const workerIdle = 5 * time.Second
+
+/**
+Workers are the goroutines reused by the calls of Run, there are at most max - 1 of them,
+because the goroutine which calls Run runs the tasks too, they are started when they are needed
+and exit after they are idle for a while, so an unused Workers does not keep any goroutine
+*/
+type Workers struct {
+	max     int
+	running int32
+	jobs    chan func()
+	done    chan struct{}
+	close   sync.Once
+}
+
+// max <= 0 means one goroutine per task, and no goroutine is reused, a nil Workers is the same
+func NewWorkers(max int) *Workers {
+	return &Workers{max: max, jobs: make(chan func()), done: make(chan struct{})}
+}
+
+/**
+stop the idle goroutines at once, the busy ones exit after their jobs,
+the calls of Run which are running or come later run their tasks in the calling goroutines
+*/
+func (w *Workers) Close() {
+	if w == nil {
+		return
+	}
+	w.close.Do(func() { close(w.done) })
+}
+
+/**
+run the tasks 0..count-1 like Parallel, with at most max goroutines including the calling one,
+the tasks are run by the calling goroutine alone when all the goroutines of the workers are busy,
+so a task may call Run again without deadlock
+*/
+func (w *Workers) Run(count int, task func(i int) bool) {
+	if w == nil || w.max <= 0 {
+		Parallel(0, count, task)
+		return
+	}
+	if count <= 0 {
+		return
+	}
+
+	var next int64 = -1
+	var stopped int32
+	loop := func() {
+		for atomic.LoadInt32(&stopped) == 0 {
+			i := int(atomic.AddInt64(&next, 1))
+			if i >= count {
+				return
+			}
+			if !task(i) {
+				atomic.StoreInt32(&stopped, 1)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for h := 1; h < w.max && h < count; h++ {
+		wg.Add(1)
+		if !w.submit(func() {
+			defer wg.Done()
+			loop()
+		}) {
+			wg.Done()
+			break
+		}
+	}
+	loop()
+	wg.Wait()
+}
+
+// give the job to an idle goroutine, or start a new one, false when all of them are busy
+func (w *Workers) submit(job func()) bool {
+	select {
+	case <-w.done:
+		return false
+	case w.jobs <- job:
+		return true
+	default:
+	}
+	for {
+		n := atomic.LoadInt32(&w.running)
+		if int(n) >= w.max-1 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&w.running, n, n+1) {
+			go w.work(job)
+			return true
+		}
+	}
+}
+
+func (w *Workers) work(job func()) {
+	defer atomic.AddInt32(&w.running, -1)
+	idle := time.NewTimer(workerIdle)
+	defer idle.Stop()
+	for {
+		job()
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		idle.Reset(workerIdle)
+		select {
+		case job = <-w.jobs:
+		case <-idle.C:
+			return
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+/**
+the trace of one execution, it records which rules ran, the conditions they saw
+and the assignments they made, it can be serialized to json
+*/
+type Trace struct {
+	lock    sync.Mutex
+	Start   time.Time    `json:"start"`
+	End     time.Time    `json:"end"`
+	Rules   []*RuleTrace `json:"rules"`
+	current map[string]*RuleTrace
+}
+
+/**
+one execution of a rule, it starts with the evaluation of the when condition,
+a rule whose when condition is false is traced as skipped with the condition only
+*/
+type RuleTrace struct {
+	lock        sync.Mutex
+	RuleName    string             `json:"rule"`
+	Start       time.Time          `json:"start"`
+	End         time.Time          `json:"end"`
+	Conditions  []*ConditionTrace  `json:"conditions,omitempty"`
+	Assignments []*AssignmentTrace `json:"assignments,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Skipped     bool               `json:"skipped,omitempty"`
+	open        []*ConditionTrace
+}
+
+// the condition of a when, if or else if, with the operands of the comparisons in it
+type ConditionTrace struct {
+	Kind     string          `json:"kind"` // "when", "if" or "else if"
+	Code     string          `json:"code"`
+	LineNum  int             `json:"line"`
+	Column   int             `json:"column"`
+	Operands []*OperandTrace `json:"operands,omitempty"`
+	Result   bool            `json:"result"`
+}
+
+// a comparison evaluated in a condition
+type OperandTrace struct {
+	Code     string      `json:"code"`
+	Left     interface{} `json:"left"`
+	Operator string      `json:"operator"`
+	Right    interface{} `json:"right"`
+	Result   interface{} `json:"result"`
+}
+
+type AssignmentTrace struct {
+	Target  string      `json:"target"`
+	Code    string      `json:"code"`
+	LineNum int         `json:"line"`
+	Column  int         `json:"column"`
+	Old     interface{} `json:"old"`
+	New     interface{} `json:"new"`
+}
+
+func NewTrace() *Trace {
+	return &Trace{
+		Start:   time.Now(),
+		current: make(map[string]*RuleTrace),
+	}
+}
+
+func (t *Trace) Finish() {
+	t.lock.Lock()
+	t.End = time.Now()
+	t.lock.Unlock()
+}
+
+// start to trace an execution of the rule
+func (t *Trace) BeginRule(ruleName string) *RuleTrace {
+	rt := &RuleTrace{RuleName: ruleName, Start: time.Now()}
+	t.lock.Lock()
+	t.Rules = append(t.Rules, rt)
+	t.current[ruleName] = rt
+	t.lock.Unlock()
+	return rt
+}
+
+// the trace of the running execution of the rule, nil when it is not traced
+func (t *Trace) Rule(ruleName string) *RuleTrace {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.current[ruleName]
+}
+
+func (rt *RuleTrace) Finish(err error) {
+	rt.lock.Lock()
+	rt.End = time.Now()
+	if err != nil {
+		rt.Error = err.Error()
+	}
+	rt.lock.Unlock()
+}
+
+// the when condition of the rule is false, it is not executed
+func (rt *RuleTrace) Skip() {
+	rt.lock.Lock()
+	rt.End = time.Now()
+	rt.Skipped = true
+	rt.lock.Unlock()
+}
+
+// start to evaluate a condition, the comparisons evaluated before EndCondition are recorded in it
+func (rt *RuleTrace) BeginCondition(kind, code string, lineNum, column int) *ConditionTrace {
+	c := &ConditionTrace{Kind: kind, Code: code, LineNum: lineNum, Column: column}
+	rt.lock.Lock()
+	rt.Conditions = append(rt.Conditions, c)
+	rt.open = append(rt.open, c)
+	rt.lock.Unlock()
+	return c
+}
+
+func (rt *RuleTrace) EndCondition(c *ConditionTrace, result bool) {
+	rt.lock.Lock()
+	c.Result = result
+	if n := len(rt.open); n > 0 {
+		rt.open = rt.open[:n-1]
+	}
+	rt.lock.Unlock()
+}
+
+// record a comparison, it is ignored when it is not in a condition
+func (rt *RuleTrace) Operand(code string, left interface{}, operator string, right interface{}, result interface{}) {
+	rt.lock.Lock()
+	if n := len(rt.open); n > 0 {
+		c := rt.open[n-1]
+		c.Operands = append(c.Operands, &OperandTrace{Code: code, Left: left, Operator: operator, Right: right, Result: result})
+	}
+	rt.lock.Unlock()
+}
+
+func (rt *RuleTrace) Assignment(target, code string, lineNum, column int, old, new interface{}) {
+	rt.lock.Lock()
+	rt.Assignments = append(rt.Assignments, &AssignmentTrace{Target: target, Code: code, LineNum: lineNum, Column: column, Old: old, New: new})
+	rt.lock.Unlock()
+}
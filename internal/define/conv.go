@@ -0,0 +1,143 @@
+package define
+
+import (
+	"fmt"
+	"gengine/internal/core"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/**
+builtin conversion functions, use it in rule like this:
+	Order.Count = conv.ToInt(Order.CountStr)
+
+the To* functions accept numbers, decimals, bools and strings,
+the Parse* functions accept strings only
+*/
+type ConvWrapper struct{}
+
+// floats are truncated toward zero, a value out of the range of int64, NaN and Inf are errors
+func (c *ConvWrapper) ToInt(v interface{}) int64 {
+	if d, ok := v.(core.Decimal); ok {
+		i, e := core.DecimalToKind(d, reflect.Int64)
+		if e != nil {
+			panic(fmt.Sprintf("conv: %+v", e))
+		}
+		return i.(int64)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			panic(fmt.Sprintf("conv: %d overflows int64", rv.Uint()))
+		}
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return floatToInt(rv.Float())
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.String:
+		s := strings.TrimSpace(rv.String())
+		i, e := strconv.ParseInt(s, 10, 64)
+		if e == nil {
+			return i
+		}
+		if ne, ok := e.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			panic(fmt.Sprintf("conv: %s overflows int64", s))
+		}
+		return floatToInt(c.ParseFloat(s))
+	}
+	panic(fmt.Sprintf("conv: %s can't be converted to int", rv.Kind().String()))
+}
+
+// truncated toward zero, -2^63 <= f < 2^63 fits in int64
+func floatToInt(f float64) int64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("conv: %v can't be converted to int", f))
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		panic(fmt.Sprintf("conv: %v overflows int64", f))
+	}
+	return int64(f)
+}
+
+func (c *ConvWrapper) ToFloat(v interface{}) float64 {
+	if d, ok := v.(core.Decimal); ok {
+		f, e := d.Float64()
+		if e != nil {
+			panic(fmt.Sprintf("conv: %+v", e))
+		}
+		return f
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.String:
+		return c.ParseFloat(strings.TrimSpace(rv.String()))
+	}
+	panic(fmt.Sprintf("conv: %s can't be converted to float", rv.Kind().String()))
+}
+
+// floats use the shortest representation, like 0.1 rather than 0.100000
+func (c *ConvWrapper) ToString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if d, ok := v.(core.Decimal); ok {
+		return d.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// base 10
+func (c *ConvWrapper) ParseInt(s string) int64 {
+	i, e := strconv.ParseInt(s, 10, 64)
+	if e != nil {
+		panic(fmt.Sprintf("conv: %+v", e))
+	}
+	return i
+}
+
+func (c *ConvWrapper) ParseFloat(s string) float64 {
+	f, e := strconv.ParseFloat(s, 64)
+	if e != nil {
+		panic(fmt.Sprintf("conv: %+v", e))
+	}
+	return f
+}
+
+// accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False
+func (c *ConvWrapper) ParseBool(s string) bool {
+	b, e := strconv.ParseBool(s)
+	if e != nil {
+		panic(fmt.Sprintf("conv: %+v", e))
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+package define
+
+import (
+	"fmt"
+	"gengine/internal/core"
+	"reflect"
+)
+
+// rounding builtins for decimal mode, they also accept go numbers
+type DecimalWrapper struct{}
+
+func (d *DecimalWrapper) New(v interface{}) core.Decimal {
+	return toDecimal(v)
+}
+
+func (d *DecimalWrapper) Round(v interface{}, places int) core.Decimal {
+	return toDecimal(v).Round(int32(places))
+}
+
+func (d *DecimalWrapper) RoundBank(v interface{}, places int) core.Decimal {
+	return toDecimal(v).RoundBank(int32(places))
+}
+
+func (d *DecimalWrapper) Truncate(v interface{}, places int) core.Decimal {
+	return toDecimal(v).Truncate(int32(places))
+}
+
+func (d *DecimalWrapper) Floor(v interface{}, places int) core.Decimal {
+	return toDecimal(v).Floor(int32(places))
+}
+
+func (d *DecimalWrapper) Ceil(v interface{}, places int) core.Decimal {
+	return toDecimal(v).Ceil(int32(places))
+}
+
+// a value out of the range of float64 is an error
+func (d *DecimalWrapper) ToFloat(v interface{}) float64 {
+	f, e := toDecimal(v).Float64()
+	if e != nil {
+		panic(fmt.Sprintf("decimal: %+v", e))
+	}
+	return f
+}
+
+// the fraction is truncated, a value out of the range of int64 is an error
+func (d *DecimalWrapper) ToInt(v interface{}) int64 {
+	i, e := core.DecimalToKind(toDecimal(v), reflect.Int64)
+	if e != nil {
+		panic(fmt.Sprintf("decimal: %+v", e))
+	}
+	return i.(int64)
+}
+
+func (d *DecimalWrapper) String(v interface{}) string {
+	return toDecimal(v).String()
+}
+
+// the panic will be recovered by MethodCall, and reported with the line of the rule
+func toDecimal(v interface{}) core.Decimal {
+	dec, e := core.ToDecimal(v)
+	if e != nil {
+		panic(fmt.Sprintf("decimal: %+v", e))
+	}
+	return dec
+}
@@ -0,0 +1,89 @@
+package define
+
+import (
+	"fmt"
+	"gengine/internal/core"
+	"math"
+	"reflect"
+)
+
+/**
+builtin math functions, use it in rule like this:
+	Order.Total = math.Max(Order.Total, 100)
+
+Min, Max and Abs keep the type of the value, the others work on float64
+*/
+type MathWrapper struct{}
+
+func (m *MathWrapper) Min(a, b interface{}) interface{} {
+	if compare(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func (m *MathWrapper) Max(a, b interface{}) interface{} {
+	if compare(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func (m *MathWrapper) Abs(v interface{}) interface{} {
+	if d, ok := v.(core.Decimal); ok {
+		return d.Abs()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() == math.MinInt64 {
+			panic(fmt.Sprintf("math: Abs(%d) overflows int64", rv.Int()))
+		}
+		if rv.Int() < 0 {
+			return -rv.Int()
+		}
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return math.Abs(rv.Float())
+	}
+	panic(fmt.Sprintf("math: Abs can't be used on %s", rv.Kind().String()))
+}
+
+func (m *MathWrapper) Floor(f float64) float64 {
+	return math.Floor(f)
+}
+
+func (m *MathWrapper) Ceil(f float64) float64 {
+	return math.Ceil(f)
+}
+
+// round half away from zero
+func (m *MathWrapper) Round(f float64) float64 {
+	return math.Round(f)
+}
+
+func (m *MathWrapper) Pow(x, y float64) float64 {
+	return math.Pow(x, y)
+}
+
+func compare(a, b interface{}) int {
+	if core.IsDecimal(a) || core.IsDecimal(b) {
+		c, e := core.CompareDecimal(a, b)
+		if e != nil {
+			panic(fmt.Sprintf("math: %+v", e))
+		}
+		return c
+	}
+
+	c, ok, e := core.CompareNumber(a, b)
+	if e != nil {
+		panic(fmt.Sprintf("math: %+v", e))
+	}
+	if !ok {
+		panic(fmt.Sprintf("math: can't compare %v with %v", a, b))
+	}
+	return c
+}
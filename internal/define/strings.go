@@ -0,0 +1,55 @@
+package define
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+builtin string functions, use it in rule like this:
+	if strings.HasPrefix(User.Name, "vip_") { ... }
+*/
+type StringsWrapper struct{}
+
+func (s *StringsWrapper) Contains(str, substr string) bool {
+	return strings.Contains(str, substr)
+}
+
+func (s *StringsWrapper) HasPrefix(str, prefix string) bool {
+	return strings.HasPrefix(str, prefix)
+}
+
+func (s *StringsWrapper) HasSuffix(str, suffix string) bool {
+	return strings.HasSuffix(str, suffix)
+}
+
+func (s *StringsWrapper) Split(str, sep string) []string {
+	return strings.Split(str, sep)
+}
+
+func (s *StringsWrapper) Join(elems []string, sep string) string {
+	return strings.Join(elems, sep)
+}
+
+func (s *StringsWrapper) Lower(str string) string {
+	return strings.ToLower(str)
+}
+
+func (s *StringsWrapper) Upper(str string) string {
+	return strings.ToUpper(str)
+}
+
+// remove the leading and trailing white space
+func (s *StringsWrapper) Trim(str string) string {
+	return strings.TrimSpace(str)
+}
+
+// replace all old with new
+func (s *StringsWrapper) Replace(str, old, new string) string {
+	return strings.ReplaceAll(str, old, new)
+}
+
+// the number of characters, not bytes
+func (s *StringsWrapper) Len(str string) int {
+	return utf8.RuneCountInString(str)
+}
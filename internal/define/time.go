@@ -0,0 +1,139 @@
+package define
+
+import (
+	"fmt"
+	"gengine/internal/core"
+	"reflect"
+	"time"
+)
+
+/**
+builtin time functions, use it in rule like this:
+	t := time.Parse("2006-01-02", "2020-12-01")
+	if time.DiffDays(time.Now(), t) > 30 { ... }
+
+durations are int64 nanoseconds in rules, or strings like "1h30m"
+*/
+type TimeWrapper struct {
+	now func() time.Time
+}
+
+// now is the clock, it is time.Now when nil
+func NewTimeWrapper(now func() time.Time) *TimeWrapper {
+	if now == nil {
+		now = time.Now
+	}
+	return &TimeWrapper{now: now}
+}
+
+func (t *TimeWrapper) Now() time.Time {
+	return t.now()
+}
+
+func (t *TimeWrapper) Parse(layout, value string) time.Time {
+	tm, e := time.Parse(layout, value)
+	if e != nil {
+		panic(fmt.Sprintf("time: %+v", e))
+	}
+	return tm
+}
+
+func (t *TimeWrapper) ParseInLocation(layout, value, tz string) time.Time {
+	tm, e := time.ParseInLocation(layout, value, location(tz))
+	if e != nil {
+		panic(fmt.Sprintf("time: %+v", e))
+	}
+	return tm
+}
+
+func (t *TimeWrapper) Format(tm time.Time, layout string) string {
+	return tm.Format(layout)
+}
+
+func (t *TimeWrapper) ParseDuration(s string) time.Duration {
+	return duration(s)
+}
+
+// d is a duration string like "24h" or nanoseconds
+func (t *TimeWrapper) Add(tm time.Time, d interface{}) time.Time {
+	return tm.Add(duration(d))
+}
+
+func (t *TimeWrapper) AddDate(tm time.Time, years, months, days int) time.Time {
+	return tm.AddDate(years, months, days)
+}
+
+// a - b
+func (t *TimeWrapper) Sub(a, b time.Time) time.Duration {
+	return a.Sub(b)
+}
+
+// the number of whole 24 hours in a - b
+func (t *TimeWrapper) DiffDays(a, b time.Time) int64 {
+	return int64(a.Sub(b) / (24 * time.Hour))
+}
+
+// the number of whole hours in a - b
+func (t *TimeWrapper) DiffHours(a, b time.Time) int64 {
+	return int64(a.Sub(b) / time.Hour)
+}
+
+// 0 is Sunday, 6 is Saturday
+func (t *TimeWrapper) Weekday(tm time.Time) int {
+	return int(tm.Weekday())
+}
+
+// the first moment of the day in the timezone, tz like "Asia/Shanghai", "" means the location of tm
+func (t *TimeWrapper) StartOfDay(tm time.Time, tz string) time.Time {
+	if tz != "" {
+		tm = tm.In(location(tz))
+	}
+	y, m, d := tm.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, tm.Location())
+}
+
+func (t *TimeWrapper) Unix(tm time.Time) int64 {
+	return tm.Unix()
+}
+
+func (t *TimeWrapper) FromUnix(sec int64) time.Time {
+	return time.Unix(sec, 0)
+}
+
+func location(tz string) *time.Location {
+	if tz == "" {
+		return time.Local
+	}
+	loc, e := time.LoadLocation(tz)
+	if e != nil {
+		panic(fmt.Sprintf("time: %+v", e))
+	}
+	return loc
+}
+
+func duration(d interface{}) time.Duration {
+	if s, ok := d.(string); ok {
+		du, e := time.ParseDuration(s)
+		if e != nil {
+			panic(fmt.Sprintf("time: %+v", e))
+		}
+		return du
+	}
+
+	if dec, ok := d.(core.Decimal); ok {
+		i, e := core.DecimalToKind(dec, reflect.Int64)
+		if e != nil {
+			panic(fmt.Sprintf("time: %+v", e))
+		}
+		return time.Duration(i.(int64))
+	}
+
+	v := reflect.ValueOf(d)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return time.Duration(v.Uint())
+	}
+	panic(fmt.Sprintf("time: %s can't be used as duration", v.Kind().String()))
+}
@@ -51,6 +51,60 @@ func (s *BasegengineListener) EnterSalience(ctx *SalienceContext) {}
 // ExitSalience is called when production salience is exited.
 func (s *BasegengineListener) ExitSalience(ctx *SalienceContext) {}
 
+// EnterRuleAttribute is called when production ruleAttribute is entered.
+func (s *BasegengineListener) EnterRuleAttribute(ctx *RuleAttributeContext) {}
+
+// ExitRuleAttribute is called when production ruleAttribute is exited.
+func (s *BasegengineListener) ExitRuleAttribute(ctx *RuleAttributeContext) {}
+
+// EnterNoLoop is called when production noLoop is entered.
+func (s *BasegengineListener) EnterNoLoop(ctx *NoLoopContext) {}
+
+// ExitNoLoop is called when production noLoop is exited.
+func (s *BasegengineListener) ExitNoLoop(ctx *NoLoopContext) {}
+
+// EnterWhenCondition is called when production whenCondition is entered.
+func (s *BasegengineListener) EnterWhenCondition(ctx *WhenConditionContext) {}
+
+// ExitWhenCondition is called when production whenCondition is exited.
+func (s *BasegengineListener) ExitWhenCondition(ctx *WhenConditionContext) {}
+
+// EnterAfterRules is called when production afterRules is entered.
+func (s *BasegengineListener) EnterAfterRules(ctx *AfterRulesContext) {}
+
+// ExitAfterRules is called when production afterRules is exited.
+func (s *BasegengineListener) ExitAfterRules(ctx *AfterRulesContext) {}
+
+// EnterGroupName is called when production groupName is entered.
+func (s *BasegengineListener) EnterGroupName(ctx *GroupNameContext) {}
+
+// ExitGroupName is called when production groupName is exited.
+func (s *BasegengineListener) ExitGroupName(ctx *GroupNameContext) {}
+
+// EnterRollbackStmt is called when production rollbackStmt is entered.
+func (s *BasegengineListener) EnterRollbackStmt(ctx *RollbackStmtContext) {}
+
+// ExitRollbackStmt is called when production rollbackStmt is exited.
+func (s *BasegengineListener) ExitRollbackStmt(ctx *RollbackStmtContext) {}
+
+// EnterSoftKeyword is called when production softKeyword is entered.
+func (s *BasegengineListener) EnterSoftKeyword(ctx *SoftKeywordContext) {}
+
+// ExitSoftKeyword is called when production softKeyword is exited.
+func (s *BasegengineListener) ExitSoftKeyword(ctx *SoftKeywordContext) {}
+
+// EnterHaltStmt is called when production haltStmt is entered.
+func (s *BasegengineListener) EnterHaltStmt(ctx *HaltStmtContext) {}
+
+// ExitHaltStmt is called when production haltStmt is exited.
+func (s *BasegengineListener) ExitHaltStmt(ctx *HaltStmtContext) {}
+
+// EnterExitStmt is called when production exitStmt is entered.
+func (s *BasegengineListener) EnterExitStmt(ctx *ExitStmtContext) {}
+
+// ExitExitStmt is called when production exitStmt is exited.
+func (s *BasegengineListener) ExitExitStmt(ctx *ExitStmtContext) {}
+
 // EnterRuleContent is called when production ruleContent is entered.
 func (s *BasegengineListener) EnterRuleContent(ctx *RuleContentContext) {}
 
@@ -28,6 +28,42 @@ func (v *BasegengineVisitor) VisitSalience(ctx *SalienceContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BasegengineVisitor) VisitRuleAttribute(ctx *RuleAttributeContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitNoLoop(ctx *NoLoopContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitWhenCondition(ctx *WhenConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitAfterRules(ctx *AfterRulesContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitGroupName(ctx *GroupNameContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitRollbackStmt(ctx *RollbackStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitSoftKeyword(ctx *SoftKeywordContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitHaltStmt(ctx *HaltStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BasegengineVisitor) VisitExitStmt(ctx *ExitStmtContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BasegengineVisitor) VisitRuleContent(ctx *RuleContentContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -14,7 +14,7 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 51, 458,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 58, 516,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
@@ -60,164 +60,189 @@ var serializedLexerAtn = []uint16{
 	3, 76, 3, 76, 3, 76, 6, 76, 431, 10, 76, 13, 76, 14, 76, 432, 3, 76, 3,
 	76, 5, 76, 437, 10, 76, 3, 77, 3, 77, 3, 77, 3, 77, 7, 77, 443, 10, 77,
 	12, 77, 14, 77, 446, 11, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 78, 6, 78,
-	453, 10, 78, 13, 78, 14, 78, 454, 3, 78, 3, 78, 3, 444, 2, 79, 3, 3, 5,
-	4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 2, 19, 2, 21, 2, 23, 2, 25, 2,
-	27, 2, 29, 2, 31, 2, 33, 2, 35, 2, 37, 2, 39, 2, 41, 2, 43, 2, 45, 2, 47,
-	2, 49, 2, 51, 2, 53, 2, 55, 2, 57, 2, 59, 2, 61, 2, 63, 2, 65, 2, 67, 2,
-	69, 2, 71, 2, 73, 10, 75, 11, 77, 12, 79, 13, 81, 14, 83, 15, 85, 16, 87,
-	17, 89, 18, 91, 19, 93, 20, 95, 21, 97, 22, 99, 23, 101, 24, 103, 25, 105,
-	26, 107, 27, 109, 28, 111, 29, 113, 30, 115, 31, 117, 32, 119, 33, 121,
-	34, 123, 35, 125, 36, 127, 37, 129, 38, 131, 39, 133, 40, 135, 41, 137,
-	42, 139, 43, 141, 44, 143, 45, 145, 46, 147, 47, 149, 48, 151, 49, 153,
-	50, 155, 51, 3, 2, 33, 3, 2, 50, 59, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68,
-	100, 100, 4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71,
-	103, 103, 4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74,
-	106, 106, 4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77,
-	109, 109, 4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80,
-	112, 112, 4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83,
-	115, 115, 4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86,
-	118, 118, 4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89,
-	121, 121, 4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92,
-	124, 124, 5, 2, 67, 92, 97, 97, 99, 124, 6, 2, 50, 59, 67, 92, 97, 97,
-	99, 124, 4, 2, 36, 36, 94, 94, 5, 2, 11, 12, 15, 15, 34, 34, 2, 450, 2,
-	3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2,
-	11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2,
-	2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2,
-	2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2,
-	2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3,
-	2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 2,
-	105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111, 3, 2,
-	2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2, 2, 119,
-	3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3, 2, 2, 2,
-	2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2, 133, 3,
-	2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 139, 3, 2, 2, 2, 2,
-	141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147, 3, 2,
-	2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2, 2, 155,
-	3, 2, 2, 2, 3, 157, 3, 2, 2, 2, 5, 162, 3, 2, 2, 2, 7, 165, 3, 2, 2, 2,
-	9, 170, 3, 2, 2, 2, 11, 172, 3, 2, 2, 2, 13, 178, 3, 2, 2, 2, 15, 184,
-	3, 2, 2, 2, 17, 188, 3, 2, 2, 2, 19, 190, 3, 2, 2, 2, 21, 192, 3, 2, 2,
-	2, 23, 194, 3, 2, 2, 2, 25, 196, 3, 2, 2, 2, 27, 198, 3, 2, 2, 2, 29, 200,
-	3, 2, 2, 2, 31, 202, 3, 2, 2, 2, 33, 204, 3, 2, 2, 2, 35, 206, 3, 2, 2,
-	2, 37, 208, 3, 2, 2, 2, 39, 210, 3, 2, 2, 2, 41, 212, 3, 2, 2, 2, 43, 214,
-	3, 2, 2, 2, 45, 216, 3, 2, 2, 2, 47, 218, 3, 2, 2, 2, 49, 220, 3, 2, 2,
-	2, 51, 222, 3, 2, 2, 2, 53, 224, 3, 2, 2, 2, 55, 226, 3, 2, 2, 2, 57, 228,
-	3, 2, 2, 2, 59, 230, 3, 2, 2, 2, 61, 232, 3, 2, 2, 2, 63, 234, 3, 2, 2,
-	2, 65, 236, 3, 2, 2, 2, 67, 238, 3, 2, 2, 2, 69, 240, 3, 2, 2, 2, 71, 242,
-	3, 2, 2, 2, 73, 251, 3, 2, 2, 2, 75, 255, 3, 2, 2, 2, 77, 260, 3, 2, 2,
-	2, 79, 263, 3, 2, 2, 2, 81, 266, 3, 2, 2, 2, 83, 271, 3, 2, 2, 2, 85, 277,
-	3, 2, 2, 2, 87, 282, 3, 2, 2, 2, 89, 291, 3, 2, 2, 2, 91, 297, 3, 2, 2,
-	2, 93, 302, 3, 2, 2, 2, 95, 313, 3, 2, 2, 2, 97, 317, 3, 2, 2, 2, 99, 319,
-	3, 2, 2, 2, 101, 321, 3, 2, 2, 2, 103, 323, 3, 2, 2, 2, 105, 325, 3, 2,
-	2, 2, 107, 328, 3, 2, 2, 2, 109, 330, 3, 2, 2, 2, 111, 332, 3, 2, 2, 2,
-	113, 335, 3, 2, 2, 2, 115, 338, 3, 2, 2, 2, 117, 341, 3, 2, 2, 2, 119,
-	343, 3, 2, 2, 2, 121, 346, 3, 2, 2, 2, 123, 348, 3, 2, 2, 2, 125, 351,
-	3, 2, 2, 2, 127, 354, 3, 2, 2, 2, 129, 357, 3, 2, 2, 2, 131, 360, 3, 2,
-	2, 2, 133, 362, 3, 2, 2, 2, 135, 364, 3, 2, 2, 2, 137, 366, 3, 2, 2, 2,
-	139, 368, 3, 2, 2, 2, 141, 370, 3, 2, 2, 2, 143, 372, 3, 2, 2, 2, 145,
-	374, 3, 2, 2, 2, 147, 376, 3, 2, 2, 2, 149, 389, 3, 2, 2, 2, 151, 436,
-	3, 2, 2, 2, 153, 438, 3, 2, 2, 2, 155, 452, 3, 2, 2, 2, 157, 158, 7, 101,
-	2, 2, 158, 159, 7, 113, 2, 2, 159, 160, 7, 112, 2, 2, 160, 161, 7, 101,
-	2, 2, 161, 4, 3, 2, 2, 2, 162, 163, 7, 107, 2, 2, 163, 164, 7, 104, 2,
-	2, 164, 6, 3, 2, 2, 2, 165, 166, 7, 103, 2, 2, 166, 167, 7, 110, 2, 2,
-	167, 168, 7, 117, 2, 2, 168, 169, 7, 103, 2, 2, 169, 8, 3, 2, 2, 2, 170,
-	171, 7, 46, 2, 2, 171, 10, 3, 2, 2, 2, 172, 173, 7, 66, 2, 2, 173, 174,
-	7, 112, 2, 2, 174, 175, 7, 99, 2, 2, 175, 176, 7, 111, 2, 2, 176, 177,
-	7, 103, 2, 2, 177, 12, 3, 2, 2, 2, 178, 179, 7, 66, 2, 2, 179, 180, 7,
-	102, 2, 2, 180, 181, 7, 103, 2, 2, 181, 182, 7, 117, 2, 2, 182, 183, 7,
-	101, 2, 2, 183, 14, 3, 2, 2, 2, 184, 185, 7, 66, 2, 2, 185, 186, 7, 107,
-	2, 2, 186, 187, 7, 102, 2, 2, 187, 16, 3, 2, 2, 2, 188, 189, 9, 2, 2, 2,
-	189, 18, 3, 2, 2, 2, 190, 191, 9, 3, 2, 2, 191, 20, 3, 2, 2, 2, 192, 193,
-	9, 4, 2, 2, 193, 22, 3, 2, 2, 2, 194, 195, 9, 5, 2, 2, 195, 24, 3, 2, 2,
-	2, 196, 197, 9, 6, 2, 2, 197, 26, 3, 2, 2, 2, 198, 199, 9, 7, 2, 2, 199,
-	28, 3, 2, 2, 2, 200, 201, 9, 8, 2, 2, 201, 30, 3, 2, 2, 2, 202, 203, 9,
-	9, 2, 2, 203, 32, 3, 2, 2, 2, 204, 205, 9, 10, 2, 2, 205, 34, 3, 2, 2,
-	2, 206, 207, 9, 11, 2, 2, 207, 36, 3, 2, 2, 2, 208, 209, 9, 12, 2, 2, 209,
-	38, 3, 2, 2, 2, 210, 211, 9, 13, 2, 2, 211, 40, 3, 2, 2, 2, 212, 213, 9,
-	14, 2, 2, 213, 42, 3, 2, 2, 2, 214, 215, 9, 15, 2, 2, 215, 44, 3, 2, 2,
-	2, 216, 217, 9, 16, 2, 2, 217, 46, 3, 2, 2, 2, 218, 219, 9, 17, 2, 2, 219,
-	48, 3, 2, 2, 2, 220, 221, 9, 18, 2, 2, 221, 50, 3, 2, 2, 2, 222, 223, 9,
-	19, 2, 2, 223, 52, 3, 2, 2, 2, 224, 225, 9, 20, 2, 2, 225, 54, 3, 2, 2,
-	2, 226, 227, 9, 21, 2, 2, 227, 56, 3, 2, 2, 2, 228, 229, 9, 22, 2, 2, 229,
-	58, 3, 2, 2, 2, 230, 231, 9, 23, 2, 2, 231, 60, 3, 2, 2, 2, 232, 233, 9,
-	24, 2, 2, 233, 62, 3, 2, 2, 2, 234, 235, 9, 25, 2, 2, 235, 64, 3, 2, 2,
-	2, 236, 237, 9, 26, 2, 2, 237, 66, 3, 2, 2, 2, 238, 239, 9, 27, 2, 2, 239,
-	68, 3, 2, 2, 2, 240, 241, 9, 28, 2, 2, 241, 70, 3, 2, 2, 2, 242, 244, 9,
-	7, 2, 2, 243, 245, 7, 47, 2, 2, 244, 243, 3, 2, 2, 2, 244, 245, 3, 2, 2,
-	2, 245, 247, 3, 2, 2, 2, 246, 248, 5, 17, 9, 2, 247, 246, 3, 2, 2, 2, 248,
-	249, 3, 2, 2, 2, 249, 247, 3, 2, 2, 2, 249, 250, 3, 2, 2, 2, 250, 72, 3,
-	2, 2, 2, 251, 252, 5, 45, 23, 2, 252, 253, 5, 35, 18, 2, 253, 254, 5, 41,
-	21, 2, 254, 74, 3, 2, 2, 2, 255, 256, 5, 53, 27, 2, 256, 257, 5, 59, 30,
-	2, 257, 258, 5, 41, 21, 2, 258, 259, 5, 27, 14, 2, 259, 76, 3, 2, 2, 2,
-	260, 261, 7, 40, 2, 2, 261, 262, 7, 40, 2, 2, 262, 78, 3, 2, 2, 2, 263,
-	264, 7, 126, 2, 2, 264, 265, 7, 126, 2, 2, 265, 80, 3, 2, 2, 2, 266, 267,
-	5, 57, 29, 2, 267, 268, 5, 53, 27, 2, 268, 269, 5, 59, 30, 2, 269, 270,
-	5, 27, 14, 2, 270, 82, 3, 2, 2, 2, 271, 272, 5, 29, 15, 2, 272, 273, 5,
-	19, 10, 2, 273, 274, 5, 41, 21, 2, 274, 275, 5, 55, 28, 2, 275, 276, 5,
-	27, 14, 2, 276, 84, 3, 2, 2, 2, 277, 278, 5, 45, 23, 2, 278, 279, 5, 59,
-	30, 2, 279, 280, 5, 41, 21, 2, 280, 281, 5, 41, 21, 2, 281, 86, 3, 2, 2,
-	2, 282, 283, 5, 55, 28, 2, 283, 284, 5, 19, 10, 2, 284, 285, 5, 41, 21,
-	2, 285, 286, 5, 35, 18, 2, 286, 287, 5, 27, 14, 2, 287, 288, 5, 45, 23,
-	2, 288, 289, 5, 23, 12, 2, 289, 290, 5, 27, 14, 2, 290, 88, 3, 2, 2, 2,
-	291, 292, 5, 21, 11, 2, 292, 293, 5, 27, 14, 2, 293, 294, 5, 31, 16, 2,
-	294, 295, 5, 35, 18, 2, 295, 296, 5, 45, 23, 2, 296, 90, 3, 2, 2, 2, 297,
-	298, 5, 27, 14, 2, 298, 299, 5, 45, 23, 2, 299, 300, 5, 25, 13, 2, 300,
-	92, 3, 2, 2, 2, 301, 303, 9, 29, 2, 2, 302, 301, 3, 2, 2, 2, 303, 304,
-	3, 2, 2, 2, 304, 302, 3, 2, 2, 2, 304, 305, 3, 2, 2, 2, 305, 309, 3, 2,
-	2, 2, 306, 308, 9, 30, 2, 2, 307, 306, 3, 2, 2, 2, 308, 311, 3, 2, 2, 2,
-	309, 307, 3, 2, 2, 2, 309, 310, 3, 2, 2, 2, 310, 94, 3, 2, 2, 2, 311, 309,
-	3, 2, 2, 2, 312, 314, 4, 50, 59, 2, 313, 312, 3, 2, 2, 2, 314, 315, 3,
-	2, 2, 2, 315, 313, 3, 2, 2, 2, 315, 316, 3, 2, 2, 2, 316, 96, 3, 2, 2,
-	2, 317, 318, 7, 45, 2, 2, 318, 98, 3, 2, 2, 2, 319, 320, 7, 47, 2, 2, 320,
-	100, 3, 2, 2, 2, 321, 322, 7, 49, 2, 2, 322, 102, 3, 2, 2, 2, 323, 324,
-	7, 44, 2, 2, 324, 104, 3, 2, 2, 2, 325, 326, 7, 63, 2, 2, 326, 327, 7,
-	63, 2, 2, 327, 106, 3, 2, 2, 2, 328, 329, 7, 64, 2, 2, 329, 108, 3, 2,
-	2, 2, 330, 331, 7, 62, 2, 2, 331, 110, 3, 2, 2, 2, 332, 333, 7, 64, 2,
-	2, 333, 334, 7, 63, 2, 2, 334, 112, 3, 2, 2, 2, 335, 336, 7, 62, 2, 2,
-	336, 337, 7, 63, 2, 2, 337, 114, 3, 2, 2, 2, 338, 339, 7, 35, 2, 2, 339,
-	340, 7, 63, 2, 2, 340, 116, 3, 2, 2, 2, 341, 342, 7, 35, 2, 2, 342, 118,
-	3, 2, 2, 2, 343, 344, 7, 60, 2, 2, 344, 345, 7, 63, 2, 2, 345, 120, 3,
-	2, 2, 2, 346, 347, 7, 63, 2, 2, 347, 122, 3, 2, 2, 2, 348, 349, 7, 45,
-	2, 2, 349, 350, 7, 63, 2, 2, 350, 124, 3, 2, 2, 2, 351, 352, 7, 47, 2,
-	2, 352, 353, 7, 63, 2, 2, 353, 126, 3, 2, 2, 2, 354, 355, 7, 44, 2, 2,
-	355, 356, 7, 63, 2, 2, 356, 128, 3, 2, 2, 2, 357, 358, 7, 49, 2, 2, 358,
-	359, 7, 63, 2, 2, 359, 130, 3, 2, 2, 2, 360, 361, 7, 93, 2, 2, 361, 132,
-	3, 2, 2, 2, 362, 363, 7, 95, 2, 2, 363, 134, 3, 2, 2, 2, 364, 365, 7, 61,
-	2, 2, 365, 136, 3, 2, 2, 2, 366, 367, 7, 125, 2, 2, 367, 138, 3, 2, 2,
-	2, 368, 369, 7, 127, 2, 2, 369, 140, 3, 2, 2, 2, 370, 371, 7, 42, 2, 2,
-	371, 142, 3, 2, 2, 2, 372, 373, 7, 43, 2, 2, 373, 144, 3, 2, 2, 2, 374,
-	375, 7, 48, 2, 2, 375, 146, 3, 2, 2, 2, 376, 384, 7, 36, 2, 2, 377, 378,
-	7, 94, 2, 2, 378, 383, 11, 2, 2, 2, 379, 380, 7, 36, 2, 2, 380, 383, 7,
-	36, 2, 2, 381, 383, 10, 31, 2, 2, 382, 377, 3, 2, 2, 2, 382, 379, 3, 2,
-	2, 2, 382, 381, 3, 2, 2, 2, 383, 386, 3, 2, 2, 2, 384, 382, 3, 2, 2, 2,
-	384, 385, 3, 2, 2, 2, 385, 387, 3, 2, 2, 2, 386, 384, 3, 2, 2, 2, 387,
-	388, 7, 36, 2, 2, 388, 148, 3, 2, 2, 2, 389, 390, 5, 93, 47, 2, 390, 391,
-	5, 145, 73, 2, 391, 392, 5, 93, 47, 2, 392, 150, 3, 2, 2, 2, 393, 395,
-	5, 17, 9, 2, 394, 393, 3, 2, 2, 2, 395, 396, 3, 2, 2, 2, 396, 394, 3, 2,
-	2, 2, 396, 397, 3, 2, 2, 2, 397, 399, 3, 2, 2, 2, 398, 394, 3, 2, 2, 2,
-	398, 399, 3, 2, 2, 2, 399, 400, 3, 2, 2, 2, 400, 402, 7, 48, 2, 2, 401,
-	403, 5, 17, 9, 2, 402, 401, 3, 2, 2, 2, 403, 404, 3, 2, 2, 2, 404, 402,
-	3, 2, 2, 2, 404, 405, 3, 2, 2, 2, 405, 437, 3, 2, 2, 2, 406, 408, 5, 17,
-	9, 2, 407, 406, 3, 2, 2, 2, 408, 409, 3, 2, 2, 2, 409, 407, 3, 2, 2, 2,
-	409, 410, 3, 2, 2, 2, 410, 411, 3, 2, 2, 2, 411, 412, 7, 48, 2, 2, 412,
-	413, 5, 71, 36, 2, 413, 437, 3, 2, 2, 2, 414, 416, 5, 17, 9, 2, 415, 414,
-	3, 2, 2, 2, 416, 417, 3, 2, 2, 2, 417, 415, 3, 2, 2, 2, 417, 418, 3, 2,
-	2, 2, 418, 420, 3, 2, 2, 2, 419, 415, 3, 2, 2, 2, 419, 420, 3, 2, 2, 2,
-	420, 421, 3, 2, 2, 2, 421, 423, 7, 48, 2, 2, 422, 424, 5, 17, 9, 2, 423,
-	422, 3, 2, 2, 2, 424, 425, 3, 2, 2, 2, 425, 423, 3, 2, 2, 2, 425, 426,
-	3, 2, 2, 2, 426, 427, 3, 2, 2, 2, 427, 428, 5, 71, 36, 2, 428, 437, 3,
-	2, 2, 2, 429, 431, 5, 17, 9, 2, 430, 429, 3, 2, 2, 2, 431, 432, 3, 2, 2,
-	2, 432, 430, 3, 2, 2, 2, 432, 433, 3, 2, 2, 2, 433, 434, 3, 2, 2, 2, 434,
-	435, 5, 71, 36, 2, 435, 437, 3, 2, 2, 2, 436, 398, 3, 2, 2, 2, 436, 407,
-	3, 2, 2, 2, 436, 419, 3, 2, 2, 2, 436, 430, 3, 2, 2, 2, 437, 152, 3, 2,
-	2, 2, 438, 439, 7, 49, 2, 2, 439, 440, 7, 49, 2, 2, 440, 444, 3, 2, 2,
-	2, 441, 443, 11, 2, 2, 2, 442, 441, 3, 2, 2, 2, 443, 446, 3, 2, 2, 2, 444,
-	445, 3, 2, 2, 2, 444, 442, 3, 2, 2, 2, 445, 447, 3, 2, 2, 2, 446, 444,
-	3, 2, 2, 2, 447, 448, 7, 12, 2, 2, 448, 449, 3, 2, 2, 2, 449, 450, 8, 77,
-	2, 2, 450, 154, 3, 2, 2, 2, 451, 453, 9, 32, 2, 2, 452, 451, 3, 2, 2, 2,
-	453, 454, 3, 2, 2, 2, 454, 452, 3, 2, 2, 2, 454, 455, 3, 2, 2, 2, 455,
-	456, 3, 2, 2, 2, 456, 457, 8, 78, 2, 2, 457, 156, 3, 2, 2, 2, 22, 2, 244,
-	249, 304, 307, 309, 315, 382, 384, 396, 398, 404, 409, 417, 419, 425, 432,
-	436, 444, 454, 3, 8, 2, 2,
+	453, 10, 78, 13, 78, 14, 78, 454, 3, 78, 3, 78, 4, 79, 9, 79, 3, 79, 3,
+	79, 3, 79, 3, 79, 3, 79, 4, 80, 9, 80, 3, 80, 3, 80, 3, 80, 3, 80, 3, 80,
+	3, 80, 3, 80, 3, 80, 4, 81, 9, 81, 3, 81, 3, 81, 3, 81, 3, 81, 3, 81, 3,
+	81, 4, 82, 9, 82, 3, 82, 3, 82, 3, 82, 3, 82, 3, 82, 4, 83, 9, 83, 3, 83,
+	3, 83, 3, 83, 3, 83, 3, 83, 4, 84, 9, 84, 3, 84, 3, 84, 3, 84, 3, 84, 3,
+	84, 3, 84, 4, 85, 9, 85, 3, 85, 3, 85, 3, 85, 3, 85, 3, 85, 3, 85, 3, 85,
+	3, 85, 3, 85, 3, 444, 2, 86, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15,
+	9, 17, 2, 19, 2, 21, 2, 23, 2, 25, 2, 27, 2, 29, 2, 31, 2, 33, 2, 35, 2,
+	37, 2, 39, 2, 41, 2, 43, 2, 45, 2, 47, 2, 49, 2, 51, 2, 53, 2, 55, 2, 57,
+	2, 59, 2, 61, 2, 63, 2, 65, 2, 67, 2, 69, 2, 71, 2, 73, 10, 75, 11, 77,
+	12, 79, 13, 81, 14, 83, 15, 85, 16, 87, 17, 89, 18, 91, 19, 93, 20, 95,
+	21, 97, 22, 99, 23, 101, 24, 103, 25, 105, 26, 107, 27, 109, 28, 111, 29,
+	113, 30, 115, 31, 117, 32, 119, 33, 121, 34, 123, 35, 125, 36, 127, 37,
+	129, 38, 131, 39, 133, 40, 135, 41, 137, 42, 139, 43, 141, 44, 143, 45,
+	145, 46, 147, 47, 149, 48, 151, 49, 153, 50, 155, 51, 458, 52, 465, 53,
+	475, 54, 483, 55, 490, 56, 497, 57, 505, 58, 3, 2, 33, 3, 2, 50, 59, 4,
+	2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 69, 69, 101, 101, 4, 2,
+	70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 72, 72, 104, 104, 4, 2,
+	73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 75, 75, 107, 107, 4, 2,
+	76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 78, 78, 110, 110, 4, 2,
+	79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 81, 81, 113, 113, 4, 2,
+	82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 84, 84, 116, 116, 4, 2,
+	85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 87, 87, 119, 119, 4, 2,
+	88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 90, 90, 122, 122, 4, 2,
+	91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 5, 2, 67, 92, 97, 97, 99, 124,
+	6, 2, 50, 59, 67, 92, 97, 97, 99, 124, 4, 2, 36, 36, 94, 94, 5, 2, 11,
+	12, 15, 15, 34, 34, 2, 508, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3,
+	2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15,
+	3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2,
+	79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2,
+	2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 458, 3, 2,
+	2, 2, 2, 465, 3, 2, 2, 2, 2, 475, 3, 2, 2, 2, 2, 483, 3, 2, 2, 2, 2, 490,
+	3, 2, 2, 2, 2, 497, 3, 2, 2, 2, 2, 505, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2,
+	2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2,
+	2, 2, 2, 103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109,
+	3, 2, 2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2,
+	2, 117, 3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3,
+	2, 2, 2, 2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2,
+	131, 3, 2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2,
+	2, 2, 2, 139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145,
+	3, 2, 2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2,
+	2, 153, 3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 3, 157, 3, 2, 2, 2, 5, 162, 3,
+	2, 2, 2, 7, 165, 3, 2, 2, 2, 9, 170, 3, 2, 2, 2, 11, 172, 3, 2, 2, 2, 13,
+	178, 3, 2, 2, 2, 15, 184, 3, 2, 2, 2, 17, 188, 3, 2, 2, 2, 19, 190, 3,
+	2, 2, 2, 21, 192, 3, 2, 2, 2, 23, 194, 3, 2, 2, 2, 25, 196, 3, 2, 2, 2,
+	27, 198, 3, 2, 2, 2, 29, 200, 3, 2, 2, 2, 31, 202, 3, 2, 2, 2, 33, 204,
+	3, 2, 2, 2, 35, 206, 3, 2, 2, 2, 37, 208, 3, 2, 2, 2, 39, 210, 3, 2, 2,
+	2, 41, 212, 3, 2, 2, 2, 43, 214, 3, 2, 2, 2, 45, 216, 3, 2, 2, 2, 47, 218,
+	3, 2, 2, 2, 49, 220, 3, 2, 2, 2, 51, 222, 3, 2, 2, 2, 53, 224, 3, 2, 2,
+	2, 55, 226, 3, 2, 2, 2, 57, 228, 3, 2, 2, 2, 59, 230, 3, 2, 2, 2, 61, 232,
+	3, 2, 2, 2, 63, 234, 3, 2, 2, 2, 65, 236, 3, 2, 2, 2, 67, 238, 3, 2, 2,
+	2, 69, 240, 3, 2, 2, 2, 71, 242, 3, 2, 2, 2, 73, 251, 3, 2, 2, 2, 75, 255,
+	3, 2, 2, 2, 77, 260, 3, 2, 2, 2, 79, 263, 3, 2, 2, 2, 81, 266, 3, 2, 2,
+	2, 83, 271, 3, 2, 2, 2, 85, 277, 3, 2, 2, 2, 87, 282, 3, 2, 2, 2, 89, 291,
+	3, 2, 2, 2, 91, 297, 3, 2, 2, 2, 93, 302, 3, 2, 2, 2, 95, 313, 3, 2, 2,
+	2, 97, 317, 3, 2, 2, 2, 99, 319, 3, 2, 2, 2, 101, 321, 3, 2, 2, 2, 103,
+	323, 3, 2, 2, 2, 105, 325, 3, 2, 2, 2, 107, 328, 3, 2, 2, 2, 109, 330,
+	3, 2, 2, 2, 111, 332, 3, 2, 2, 2, 113, 335, 3, 2, 2, 2, 115, 338, 3, 2,
+	2, 2, 117, 341, 3, 2, 2, 2, 119, 343, 3, 2, 2, 2, 121, 346, 3, 2, 2, 2,
+	123, 348, 3, 2, 2, 2, 125, 351, 3, 2, 2, 2, 127, 354, 3, 2, 2, 2, 129,
+	357, 3, 2, 2, 2, 131, 360, 3, 2, 2, 2, 133, 362, 3, 2, 2, 2, 135, 364,
+	3, 2, 2, 2, 137, 366, 3, 2, 2, 2, 139, 368, 3, 2, 2, 2, 141, 370, 3, 2,
+	2, 2, 143, 372, 3, 2, 2, 2, 145, 374, 3, 2, 2, 2, 147, 376, 3, 2, 2, 2,
+	149, 389, 3, 2, 2, 2, 151, 436, 3, 2, 2, 2, 153, 438, 3, 2, 2, 2, 155,
+	452, 3, 2, 2, 2, 157, 158, 7, 101, 2, 2, 158, 159, 7, 113, 2, 2, 159, 160,
+	7, 112, 2, 2, 160, 161, 7, 101, 2, 2, 161, 4, 3, 2, 2, 2, 162, 163, 7,
+	107, 2, 2, 163, 164, 7, 104, 2, 2, 164, 6, 3, 2, 2, 2, 165, 166, 7, 103,
+	2, 2, 166, 167, 7, 110, 2, 2, 167, 168, 7, 117, 2, 2, 168, 169, 7, 103,
+	2, 2, 169, 8, 3, 2, 2, 2, 170, 171, 7, 46, 2, 2, 171, 10, 3, 2, 2, 2, 172,
+	173, 7, 66, 2, 2, 173, 174, 7, 112, 2, 2, 174, 175, 7, 99, 2, 2, 175, 176,
+	7, 111, 2, 2, 176, 177, 7, 103, 2, 2, 177, 12, 3, 2, 2, 2, 178, 179, 7,
+	66, 2, 2, 179, 180, 7, 102, 2, 2, 180, 181, 7, 103, 2, 2, 181, 182, 7,
+	117, 2, 2, 182, 183, 7, 101, 2, 2, 183, 14, 3, 2, 2, 2, 184, 185, 7, 66,
+	2, 2, 185, 186, 7, 107, 2, 2, 186, 187, 7, 102, 2, 2, 187, 16, 3, 2, 2,
+	2, 188, 189, 9, 2, 2, 2, 189, 18, 3, 2, 2, 2, 190, 191, 9, 3, 2, 2, 191,
+	20, 3, 2, 2, 2, 192, 193, 9, 4, 2, 2, 193, 22, 3, 2, 2, 2, 194, 195, 9,
+	5, 2, 2, 195, 24, 3, 2, 2, 2, 196, 197, 9, 6, 2, 2, 197, 26, 3, 2, 2, 2,
+	198, 199, 9, 7, 2, 2, 199, 28, 3, 2, 2, 2, 200, 201, 9, 8, 2, 2, 201, 30,
+	3, 2, 2, 2, 202, 203, 9, 9, 2, 2, 203, 32, 3, 2, 2, 2, 204, 205, 9, 10,
+	2, 2, 205, 34, 3, 2, 2, 2, 206, 207, 9, 11, 2, 2, 207, 36, 3, 2, 2, 2,
+	208, 209, 9, 12, 2, 2, 209, 38, 3, 2, 2, 2, 210, 211, 9, 13, 2, 2, 211,
+	40, 3, 2, 2, 2, 212, 213, 9, 14, 2, 2, 213, 42, 3, 2, 2, 2, 214, 215, 9,
+	15, 2, 2, 215, 44, 3, 2, 2, 2, 216, 217, 9, 16, 2, 2, 217, 46, 3, 2, 2,
+	2, 218, 219, 9, 17, 2, 2, 219, 48, 3, 2, 2, 2, 220, 221, 9, 18, 2, 2, 221,
+	50, 3, 2, 2, 2, 222, 223, 9, 19, 2, 2, 223, 52, 3, 2, 2, 2, 224, 225, 9,
+	20, 2, 2, 225, 54, 3, 2, 2, 2, 226, 227, 9, 21, 2, 2, 227, 56, 3, 2, 2,
+	2, 228, 229, 9, 22, 2, 2, 229, 58, 3, 2, 2, 2, 230, 231, 9, 23, 2, 2, 231,
+	60, 3, 2, 2, 2, 232, 233, 9, 24, 2, 2, 233, 62, 3, 2, 2, 2, 234, 235, 9,
+	25, 2, 2, 235, 64, 3, 2, 2, 2, 236, 237, 9, 26, 2, 2, 237, 66, 3, 2, 2,
+	2, 238, 239, 9, 27, 2, 2, 239, 68, 3, 2, 2, 2, 240, 241, 9, 28, 2, 2, 241,
+	70, 3, 2, 2, 2, 242, 244, 9, 7, 2, 2, 243, 245, 7, 47, 2, 2, 244, 243,
+	3, 2, 2, 2, 244, 245, 3, 2, 2, 2, 245, 247, 3, 2, 2, 2, 246, 248, 5, 17,
+	9, 2, 247, 246, 3, 2, 2, 2, 248, 249, 3, 2, 2, 2, 249, 247, 3, 2, 2, 2,
+	249, 250, 3, 2, 2, 2, 250, 72, 3, 2, 2, 2, 251, 252, 5, 45, 23, 2, 252,
+	253, 5, 35, 18, 2, 253, 254, 5, 41, 21, 2, 254, 74, 3, 2, 2, 2, 255, 256,
+	5, 53, 27, 2, 256, 257, 5, 59, 30, 2, 257, 258, 5, 41, 21, 2, 258, 259,
+	5, 27, 14, 2, 259, 76, 3, 2, 2, 2, 260, 261, 7, 40, 2, 2, 261, 262, 7,
+	40, 2, 2, 262, 78, 3, 2, 2, 2, 263, 264, 7, 126, 2, 2, 264, 265, 7, 126,
+	2, 2, 265, 80, 3, 2, 2, 2, 266, 267, 5, 57, 29, 2, 267, 268, 5, 53, 27,
+	2, 268, 269, 5, 59, 30, 2, 269, 270, 5, 27, 14, 2, 270, 82, 3, 2, 2, 2,
+	271, 272, 5, 29, 15, 2, 272, 273, 5, 19, 10, 2, 273, 274, 5, 41, 21, 2,
+	274, 275, 5, 55, 28, 2, 275, 276, 5, 27, 14, 2, 276, 84, 3, 2, 2, 2, 277,
+	278, 5, 45, 23, 2, 278, 279, 5, 59, 30, 2, 279, 280, 5, 41, 21, 2, 280,
+	281, 5, 41, 21, 2, 281, 86, 3, 2, 2, 2, 282, 283, 5, 55, 28, 2, 283, 284,
+	5, 19, 10, 2, 284, 285, 5, 41, 21, 2, 285, 286, 5, 35, 18, 2, 286, 287,
+	5, 27, 14, 2, 287, 288, 5, 45, 23, 2, 288, 289, 5, 23, 12, 2, 289, 290,
+	5, 27, 14, 2, 290, 88, 3, 2, 2, 2, 291, 292, 5, 21, 11, 2, 292, 293, 5,
+	27, 14, 2, 293, 294, 5, 31, 16, 2, 294, 295, 5, 35, 18, 2, 295, 296, 5,
+	45, 23, 2, 296, 90, 3, 2, 2, 2, 297, 298, 5, 27, 14, 2, 298, 299, 5, 45,
+	23, 2, 299, 300, 5, 25, 13, 2, 300, 92, 3, 2, 2, 2, 301, 303, 9, 29, 2,
+	2, 302, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 302, 3, 2, 2, 2, 304,
+	305, 3, 2, 2, 2, 305, 309, 3, 2, 2, 2, 306, 308, 9, 30, 2, 2, 307, 306,
+	3, 2, 2, 2, 308, 311, 3, 2, 2, 2, 309, 307, 3, 2, 2, 2, 309, 310, 3, 2,
+	2, 2, 310, 94, 3, 2, 2, 2, 311, 309, 3, 2, 2, 2, 312, 314, 4, 50, 59, 2,
+	313, 312, 3, 2, 2, 2, 314, 315, 3, 2, 2, 2, 315, 313, 3, 2, 2, 2, 315,
+	316, 3, 2, 2, 2, 316, 96, 3, 2, 2, 2, 317, 318, 7, 45, 2, 2, 318, 98, 3,
+	2, 2, 2, 319, 320, 7, 47, 2, 2, 320, 100, 3, 2, 2, 2, 321, 322, 7, 49,
+	2, 2, 322, 102, 3, 2, 2, 2, 323, 324, 7, 44, 2, 2, 324, 104, 3, 2, 2, 2,
+	325, 326, 7, 63, 2, 2, 326, 327, 7, 63, 2, 2, 327, 106, 3, 2, 2, 2, 328,
+	329, 7, 64, 2, 2, 329, 108, 3, 2, 2, 2, 330, 331, 7, 62, 2, 2, 331, 110,
+	3, 2, 2, 2, 332, 333, 7, 64, 2, 2, 333, 334, 7, 63, 2, 2, 334, 112, 3,
+	2, 2, 2, 335, 336, 7, 62, 2, 2, 336, 337, 7, 63, 2, 2, 337, 114, 3, 2,
+	2, 2, 338, 339, 7, 35, 2, 2, 339, 340, 7, 63, 2, 2, 340, 116, 3, 2, 2,
+	2, 341, 342, 7, 35, 2, 2, 342, 118, 3, 2, 2, 2, 343, 344, 7, 60, 2, 2,
+	344, 345, 7, 63, 2, 2, 345, 120, 3, 2, 2, 2, 346, 347, 7, 63, 2, 2, 347,
+	122, 3, 2, 2, 2, 348, 349, 7, 45, 2, 2, 349, 350, 7, 63, 2, 2, 350, 124,
+	3, 2, 2, 2, 351, 352, 7, 47, 2, 2, 352, 353, 7, 63, 2, 2, 353, 126, 3,
+	2, 2, 2, 354, 355, 7, 44, 2, 2, 355, 356, 7, 63, 2, 2, 356, 128, 3, 2,
+	2, 2, 357, 358, 7, 49, 2, 2, 358, 359, 7, 63, 2, 2, 359, 130, 3, 2, 2,
+	2, 360, 361, 7, 93, 2, 2, 361, 132, 3, 2, 2, 2, 362, 363, 7, 95, 2, 2,
+	363, 134, 3, 2, 2, 2, 364, 365, 7, 61, 2, 2, 365, 136, 3, 2, 2, 2, 366,
+	367, 7, 125, 2, 2, 367, 138, 3, 2, 2, 2, 368, 369, 7, 127, 2, 2, 369, 140,
+	3, 2, 2, 2, 370, 371, 7, 42, 2, 2, 371, 142, 3, 2, 2, 2, 372, 373, 7, 43,
+	2, 2, 373, 144, 3, 2, 2, 2, 374, 375, 7, 48, 2, 2, 375, 146, 3, 2, 2, 2,
+	376, 384, 7, 36, 2, 2, 377, 378, 7, 94, 2, 2, 378, 383, 11, 2, 2, 2, 379,
+	380, 7, 36, 2, 2, 380, 383, 7, 36, 2, 2, 381, 383, 10, 31, 2, 2, 382, 377,
+	3, 2, 2, 2, 382, 379, 3, 2, 2, 2, 382, 381, 3, 2, 2, 2, 383, 386, 3, 2,
+	2, 2, 384, 382, 3, 2, 2, 2, 384, 385, 3, 2, 2, 2, 385, 387, 3, 2, 2, 2,
+	386, 384, 3, 2, 2, 2, 387, 388, 7, 36, 2, 2, 388, 148, 3, 2, 2, 2, 389,
+	390, 5, 93, 47, 2, 390, 391, 5, 145, 73, 2, 391, 392, 5, 93, 47, 2, 392,
+	150, 3, 2, 2, 2, 393, 395, 5, 17, 9, 2, 394, 393, 3, 2, 2, 2, 395, 396,
+	3, 2, 2, 2, 396, 394, 3, 2, 2, 2, 396, 397, 3, 2, 2, 2, 397, 399, 3, 2,
+	2, 2, 398, 394, 3, 2, 2, 2, 398, 399, 3, 2, 2, 2, 399, 400, 3, 2, 2, 2,
+	400, 402, 7, 48, 2, 2, 401, 403, 5, 17, 9, 2, 402, 401, 3, 2, 2, 2, 403,
+	404, 3, 2, 2, 2, 404, 402, 3, 2, 2, 2, 404, 405, 3, 2, 2, 2, 405, 437,
+	3, 2, 2, 2, 406, 408, 5, 17, 9, 2, 407, 406, 3, 2, 2, 2, 408, 409, 3, 2,
+	2, 2, 409, 407, 3, 2, 2, 2, 409, 410, 3, 2, 2, 2, 410, 411, 3, 2, 2, 2,
+	411, 412, 7, 48, 2, 2, 412, 413, 5, 71, 36, 2, 413, 437, 3, 2, 2, 2, 414,
+	416, 5, 17, 9, 2, 415, 414, 3, 2, 2, 2, 416, 417, 3, 2, 2, 2, 417, 415,
+	3, 2, 2, 2, 417, 418, 3, 2, 2, 2, 418, 420, 3, 2, 2, 2, 419, 415, 3, 2,
+	2, 2, 419, 420, 3, 2, 2, 2, 420, 421, 3, 2, 2, 2, 421, 423, 7, 48, 2, 2,
+	422, 424, 5, 17, 9, 2, 423, 422, 3, 2, 2, 2, 424, 425, 3, 2, 2, 2, 425,
+	423, 3, 2, 2, 2, 425, 426, 3, 2, 2, 2, 426, 427, 3, 2, 2, 2, 427, 428,
+	5, 71, 36, 2, 428, 437, 3, 2, 2, 2, 429, 431, 5, 17, 9, 2, 430, 429, 3,
+	2, 2, 2, 431, 432, 3, 2, 2, 2, 432, 430, 3, 2, 2, 2, 432, 433, 3, 2, 2,
+	2, 433, 434, 3, 2, 2, 2, 434, 435, 5, 71, 36, 2, 435, 437, 3, 2, 2, 2,
+	436, 398, 3, 2, 2, 2, 436, 407, 3, 2, 2, 2, 436, 419, 3, 2, 2, 2, 436,
+	430, 3, 2, 2, 2, 437, 152, 3, 2, 2, 2, 438, 439, 7, 49, 2, 2, 439, 440,
+	7, 49, 2, 2, 440, 444, 3, 2, 2, 2, 441, 443, 11, 2, 2, 2, 442, 441, 3,
+	2, 2, 2, 443, 446, 3, 2, 2, 2, 444, 445, 3, 2, 2, 2, 444, 442, 3, 2, 2,
+	2, 445, 447, 3, 2, 2, 2, 446, 444, 3, 2, 2, 2, 447, 448, 7, 12, 2, 2, 448,
+	449, 3, 2, 2, 2, 449, 450, 8, 77, 2, 2, 450, 154, 3, 2, 2, 2, 451, 453,
+	9, 32, 2, 2, 452, 451, 3, 2, 2, 2, 453, 454, 3, 2, 2, 2, 454, 452, 3, 2,
+	2, 2, 454, 455, 3, 2, 2, 2, 455, 456, 3, 2, 2, 2, 456, 457, 8, 78, 2, 2,
+	457, 156, 3, 2, 2, 2, 458, 460, 3, 2, 2, 2, 460, 461, 5, 63, 32, 2, 461,
+	462, 5, 33, 17, 2, 462, 463, 5, 27, 14, 2, 463, 464, 5, 45, 23, 2, 464,
+	459, 3, 2, 2, 2, 465, 467, 3, 2, 2, 2, 467, 468, 5, 45, 23, 2, 468, 469,
+	5, 47, 24, 2, 469, 470, 7, 47, 2, 2, 470, 471, 5, 41, 21, 2, 471, 472,
+	5, 47, 24, 2, 472, 473, 5, 47, 24, 2, 473, 474, 5, 49, 25, 2, 474, 466,
+	3, 2, 2, 2, 475, 477, 3, 2, 2, 2, 477, 478, 5, 19, 10, 2, 478, 479, 5,
+	29, 15, 2, 479, 480, 5, 57, 29, 2, 480, 481, 5, 27, 14, 2, 481, 482, 5,
+	53, 27, 2, 482, 476, 3, 2, 2, 2, 483, 485, 3, 2, 2, 2, 485, 486, 7, 106,
+	2, 2, 486, 487, 7, 99, 2, 2, 487, 488, 7, 110, 2, 2, 488, 489, 7, 118,
+	2, 2, 489, 484, 3, 2, 2, 2, 490, 492, 3, 2, 2, 2, 492, 493, 7, 103, 2,
+	2, 493, 494, 7, 122, 2, 2, 494, 495, 7, 107, 2, 2, 495, 496, 7, 118, 2,
+	2, 496, 491, 3, 2, 2, 2, 497, 499, 3, 2, 2, 2, 499, 500, 5, 31, 16, 2,
+	500, 501, 5, 53, 27, 2, 501, 502, 5, 47, 24, 2, 502, 503, 5, 59, 30, 2,
+	503, 504, 5, 49, 25, 2, 504, 498, 3, 2, 2, 2, 505, 507, 3, 2, 2, 2, 507,
+	508, 7, 116, 2, 2, 508, 509, 7, 113, 2, 2, 509, 510, 7, 110, 2, 2, 510,
+	511, 7, 110, 2, 2, 511, 512, 7, 100, 2, 2, 512, 513, 7, 99, 2, 2, 513,
+	514, 7, 101, 2, 2, 514, 515, 7, 109, 2, 2, 515, 506, 3, 2, 2, 2, 22, 2,
+	244, 249, 304, 307, 309, 315, 382, 384, 396, 398, 404, 409, 417, 419, 425,
+	432, 436, 444, 454, 3, 8, 2, 2,
 }
 
 var lexerChannelNames = []string{
@@ -233,7 +258,7 @@ var lexerLiteralNames = []string{
 	"", "'&&'", "'||'", "", "", "", "", "", "", "", "", "'+'", "'-'", "'/'",
 	"'*'", "'=='", "'>'", "'<'", "'>='", "'<='", "'!='", "'!'", "':='", "'='",
 	"'+='", "'-='", "'*='", "'/='", "'['", "']'", "';'", "'{'", "'}'", "'('",
-	"')'", "'.'",
+	"')'", "'.'", "", "", "", "", "", "", "", "", "'halt'", "'exit'", "", "'rollback'",
 }
 
 var lexerSymbolicNames = []string{
@@ -243,7 +268,7 @@ var lexerSymbolicNames = []string{
 	"NOT", "ASSIGN", "SET", "PLUSEQUAL", "MINUSEQUAL", "MULTIEQUAL", "DIVEQUAL",
 	"LSQARE", "RSQARE", "SEMICOLON", "LR_BRACE", "RR_BRACE", "LR_BRACKET",
 	"RR_BRACKET", "DOT", "DQUOTA_STRING", "DOTTEDNAME", "REAL_LITERAL", "SL_COMMENT",
-	"WS",
+	"WS", "WHEN", "NO_LOOP", "AFTER", "HALT", "EXIT", "GROUP", "ROLLBACK",
 }
 
 var lexerRuleNames = []string{
@@ -255,7 +280,8 @@ var lexerRuleNames = []string{
 	"GT", "LT", "GTE", "LTE", "NOTEQUALS", "NOT", "ASSIGN", "SET", "PLUSEQUAL",
 	"MINUSEQUAL", "MULTIEQUAL", "DIVEQUAL", "LSQARE", "RSQARE", "SEMICOLON",
 	"LR_BRACE", "RR_BRACE", "LR_BRACKET", "RR_BRACKET", "DOT", "DQUOTA_STRING",
-	"DOTTEDNAME", "REAL_LITERAL", "SL_COMMENT", "WS",
+	"DOTTEDNAME", "REAL_LITERAL", "SL_COMMENT", "WS", "WHEN", "NO_LOOP", "AFTER",
+	"HALT", "EXIT", "GROUP", "ROLLBACK",
 }
 
 type gengineLexer struct {
@@ -344,4 +370,11 @@ const (
 	gengineLexerREAL_LITERAL  = 47
 	gengineLexerSL_COMMENT    = 48
 	gengineLexerWS            = 49
+	gengineLexerWHEN          = 50
+	gengineLexerNO_LOOP       = 51
+	gengineLexerAFTER         = 52
+	gengineLexerHALT          = 53
+	gengineLexerEXIT          = 54
+	gengineLexerGROUP         = 55
+	gengineLexerROLLBACK      = 56
 )
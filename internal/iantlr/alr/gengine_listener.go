@@ -23,6 +23,33 @@ type gengineListener interface {
 	// EnterSalience is called when entering the salience production.
 	EnterSalience(c *SalienceContext)
 
+	// EnterRuleAttribute is called when entering the ruleAttribute production.
+	EnterRuleAttribute(c *RuleAttributeContext)
+
+	// EnterNoLoop is called when entering the noLoop production.
+	EnterNoLoop(c *NoLoopContext)
+
+	// EnterWhenCondition is called when entering the whenCondition production.
+	EnterWhenCondition(c *WhenConditionContext)
+
+	// EnterAfterRules is called when entering the afterRules production.
+	EnterAfterRules(c *AfterRulesContext)
+
+	// EnterGroupName is called when entering the groupName production.
+	EnterGroupName(c *GroupNameContext)
+
+	// EnterRollbackStmt is called when entering the rollbackStmt production.
+	EnterRollbackStmt(c *RollbackStmtContext)
+
+	// EnterSoftKeyword is called when entering the softKeyword production.
+	EnterSoftKeyword(c *SoftKeywordContext)
+
+	// EnterHaltStmt is called when entering the haltStmt production.
+	EnterHaltStmt(c *HaltStmtContext)
+
+	// EnterExitStmt is called when entering the exitStmt production.
+	EnterExitStmt(c *ExitStmtContext)
+
 	// EnterRuleContent is called when entering the ruleContent production.
 	EnterRuleContent(c *RuleContentContext)
 
@@ -128,6 +155,33 @@ type gengineListener interface {
 	// ExitSalience is called when exiting the salience production.
 	ExitSalience(c *SalienceContext)
 
+	// ExitRuleAttribute is called when exiting the ruleAttribute production.
+	ExitRuleAttribute(c *RuleAttributeContext)
+
+	// ExitNoLoop is called when exiting the noLoop production.
+	ExitNoLoop(c *NoLoopContext)
+
+	// ExitWhenCondition is called when exiting the whenCondition production.
+	ExitWhenCondition(c *WhenConditionContext)
+
+	// ExitAfterRules is called when exiting the afterRules production.
+	ExitAfterRules(c *AfterRulesContext)
+
+	// ExitGroupName is called when exiting the groupName production.
+	ExitGroupName(c *GroupNameContext)
+
+	// ExitRollbackStmt is called when exiting the rollbackStmt production.
+	ExitRollbackStmt(c *RollbackStmtContext)
+
+	// ExitSoftKeyword is called when exiting the softKeyword production.
+	ExitSoftKeyword(c *SoftKeywordContext)
+
+	// ExitHaltStmt is called when exiting the haltStmt production.
+	ExitHaltStmt(c *HaltStmtContext)
+
+	// ExitExitStmt is called when exiting the exitStmt production.
+	ExitExitStmt(c *ExitStmtContext)
+
 	// ExitRuleContent is called when exiting the ruleContent production.
 	ExitRuleContent(c *RuleContentContext)
 
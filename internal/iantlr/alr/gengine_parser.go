@@ -16,7 +16,7 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 51, 308,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 58, 375,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
@@ -48,25 +48,33 @@ var parserATN = []uint16{
 	3, 24, 3, 25, 3, 25, 3, 25, 5, 25, 275, 10, 25, 3, 25, 3, 25, 3, 26, 3,
 	26, 3, 27, 3, 27, 3, 28, 3, 28, 3, 29, 3, 29, 3, 30, 3, 30, 3, 31, 3, 31,
 	3, 32, 3, 32, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 5, 33, 298, 10, 33, 3,
-	33, 3, 33, 3, 34, 3, 34, 3, 35, 3, 35, 3, 36, 3, 36, 3, 36, 2, 4, 20, 22,
-	37, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36,
-	38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 2,
-	9, 3, 2, 14, 15, 4, 2, 20, 20, 48, 48, 3, 2, 22, 23, 3, 2, 24, 25, 3, 2,
-	26, 31, 3, 2, 12, 13, 3, 2, 33, 38, 2, 326, 2, 73, 3, 2, 2, 2, 4, 77, 3,
+	33, 3, 33, 3, 34, 3, 34, 3, 35, 3, 35, 3, 36, 3, 36, 3, 36, 4, 37, 9, 37,
+	4, 38, 9, 38, 4, 39, 9, 39, 3, 37, 10, 37, 5, 37, 315, 3, 37, 3, 37, 3,
+	38, 3, 38, 3, 39, 3, 39, 3, 39, 12, 3, 10, 3, 7, 3, 325, 11, 3, 14, 3,
+	327, 3, 3, 4, 40, 9, 40, 3, 37, 3, 40, 12, 40, 10, 40, 7, 40, 335, 11,
+	40, 14, 40, 337, 3, 40, 3, 40, 3, 40, 3, 40, 4, 41, 9, 41, 4, 42, 9, 42,
+	3, 9, 3, 9, 3, 41, 3, 41, 3, 42, 3, 42, 4, 43, 9, 43, 3, 37, 3, 43, 3,
+	43, 3, 43, 4, 44, 9, 44, 3, 9, 3, 44, 3, 44, 4, 45, 9, 45, 3, 45, 3, 45,
+	10, 26, 5, 26, 368, 3, 26, 3, 26, 10, 24, 5, 24, 372, 3, 24, 2, 4, 20,
+	22, 46, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34,
+	36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70,
+	308, 310, 312, 330, 343, 345, 353, 359, 364, 2, 10, 3, 2, 14, 15, 4, 2,
+	20, 20, 48, 48, 3, 2, 22, 23, 3, 2, 24, 25, 3, 2, 26, 31, 3, 2, 12, 13,
+	3, 2, 33, 38, 4, 2, 52, 52, 54, 58, 2, 395, 2, 73, 3, 2, 2, 2, 4, 77, 3,
 	2, 2, 2, 6, 89, 3, 2, 2, 2, 8, 91, 3, 2, 2, 2, 10, 93, 3, 2, 2, 2, 12,
 	96, 3, 2, 2, 2, 14, 99, 3, 2, 2, 2, 16, 108, 3, 2, 2, 2, 18, 110, 3, 2,
 	2, 2, 20, 135, 3, 2, 2, 2, 22, 156, 3, 2, 2, 2, 24, 176, 3, 2, 2, 2, 26,
 	180, 3, 2, 2, 2, 28, 187, 3, 2, 2, 2, 30, 203, 3, 2, 2, 2, 32, 212, 3,
 	2, 2, 2, 34, 226, 3, 2, 2, 2, 36, 234, 3, 2, 2, 2, 38, 251, 3, 2, 2, 2,
-	40, 256, 3, 2, 2, 2, 42, 260, 3, 2, 2, 2, 44, 262, 3, 2, 2, 2, 46, 264,
-	3, 2, 2, 2, 48, 271, 3, 2, 2, 2, 50, 278, 3, 2, 2, 2, 52, 280, 3, 2, 2,
+	40, 256, 3, 2, 2, 2, 42, 260, 3, 2, 2, 2, 44, 262, 3, 2, 2, 2, 46, 373,
+	3, 2, 2, 2, 48, 271, 3, 2, 2, 2, 50, 369, 3, 2, 2, 2, 52, 280, 3, 2, 2,
 	2, 54, 282, 3, 2, 2, 2, 56, 284, 3, 2, 2, 2, 58, 286, 3, 2, 2, 2, 60, 288,
 	3, 2, 2, 2, 62, 290, 3, 2, 2, 2, 64, 292, 3, 2, 2, 2, 66, 301, 3, 2, 2,
 	2, 68, 303, 3, 2, 2, 2, 70, 305, 3, 2, 2, 2, 72, 74, 5, 4, 3, 2, 73, 72,
 	3, 2, 2, 2, 74, 75, 3, 2, 2, 2, 75, 73, 3, 2, 2, 2, 75, 76, 3, 2, 2, 2,
 	76, 3, 3, 2, 2, 2, 77, 78, 7, 11, 2, 2, 78, 80, 5, 6, 4, 2, 79, 81, 5,
 	8, 5, 2, 80, 79, 3, 2, 2, 2, 80, 81, 3, 2, 2, 2, 81, 83, 3, 2, 2, 2, 82,
-	84, 5, 10, 6, 2, 83, 82, 3, 2, 2, 2, 83, 84, 3, 2, 2, 2, 84, 85, 3, 2,
+	84, 5, 10, 6, 2, 83, 82, 3, 2, 2, 2, 83, 84, 3, 2, 2, 2, 84, 324, 3, 2,
 	2, 2, 85, 86, 7, 18, 2, 2, 86, 87, 5, 12, 7, 2, 87, 88, 7, 19, 2, 2, 88,
 	5, 3, 2, 2, 2, 89, 90, 5, 42, 22, 2, 90, 7, 3, 2, 2, 2, 91, 92, 5, 42,
 	22, 2, 92, 9, 3, 2, 2, 2, 93, 94, 7, 17, 2, 2, 94, 95, 5, 38, 20, 2, 95,
@@ -137,30 +145,52 @@ var parserATN = []uint16{
 	257, 7, 23, 2, 2, 256, 255, 3, 2, 2, 2, 256, 257, 3, 2, 2, 2, 257, 258,
 	3, 2, 2, 2, 258, 259, 7, 49, 2, 2, 259, 41, 3, 2, 2, 2, 260, 261, 7, 47,
 	2, 2, 261, 43, 3, 2, 2, 2, 262, 263, 9, 2, 2, 2, 263, 45, 3, 2, 2, 2, 264,
-	265, 7, 20, 2, 2, 265, 267, 7, 44, 2, 2, 266, 268, 5, 36, 19, 2, 267, 266,
+	372, 7, 20, 2, 2, 265, 267, 7, 44, 2, 2, 266, 268, 5, 36, 19, 2, 267, 266,
 	3, 2, 2, 2, 267, 268, 3, 2, 2, 2, 268, 269, 3, 2, 2, 2, 269, 270, 7, 45,
 	2, 2, 270, 47, 3, 2, 2, 2, 271, 272, 7, 48, 2, 2, 272, 274, 7, 44, 2, 2,
 	273, 275, 5, 36, 19, 2, 274, 273, 3, 2, 2, 2, 274, 275, 3, 2, 2, 2, 275,
-	276, 3, 2, 2, 2, 276, 277, 7, 45, 2, 2, 277, 49, 3, 2, 2, 2, 278, 279,
-	9, 3, 2, 2, 279, 51, 3, 2, 2, 2, 280, 281, 9, 4, 2, 2, 281, 53, 3, 2, 2,
-	2, 282, 283, 9, 5, 2, 2, 283, 55, 3, 2, 2, 2, 284, 285, 9, 6, 2, 2, 285,
-	57, 3, 2, 2, 2, 286, 287, 9, 7, 2, 2, 287, 59, 3, 2, 2, 2, 288, 289, 9,
-	8, 2, 2, 289, 61, 3, 2, 2, 2, 290, 291, 7, 32, 2, 2, 291, 63, 3, 2, 2,
-	2, 292, 293, 5, 50, 26, 2, 293, 297, 7, 39, 2, 2, 294, 298, 5, 38, 20,
+	276, 3, 2, 2, 2, 276, 277, 7, 45, 2, 2, 277, 49, 3, 2, 2, 2, 278, 368,
+	7, 20, 2, 2, 279, 51, 3, 2, 2, 2, 280, 281, 9, 4, 2, 2, 281, 53, 3, 2,
+	2, 2, 282, 283, 9, 5, 2, 2, 283, 55, 3, 2, 2, 2, 284, 285, 9, 6, 2, 2,
+	285, 57, 3, 2, 2, 2, 286, 287, 9, 7, 2, 2, 287, 59, 3, 2, 2, 2, 288, 289,
+	9, 8, 2, 2, 289, 61, 3, 2, 2, 2, 290, 291, 7, 32, 2, 2, 291, 63, 3, 2,
+	2, 2, 292, 293, 5, 50, 26, 2, 293, 297, 7, 39, 2, 2, 294, 298, 5, 38, 20,
 	2, 295, 298, 5, 42, 22, 2, 296, 298, 5, 50, 26, 2, 297, 294, 3, 2, 2, 2,
 	297, 295, 3, 2, 2, 2, 297, 296, 3, 2, 2, 2, 298, 299, 3, 2, 2, 2, 299,
 	300, 7, 40, 2, 2, 300, 65, 3, 2, 2, 2, 301, 302, 7, 7, 2, 2, 302, 67, 3,
 	2, 2, 2, 303, 304, 7, 8, 2, 2, 304, 69, 3, 2, 2, 2, 305, 306, 7, 9, 2,
-	2, 306, 71, 3, 2, 2, 2, 34, 75, 80, 83, 101, 108, 115, 117, 125, 129, 135,
-	145, 147, 156, 166, 168, 176, 180, 185, 191, 197, 201, 208, 215, 226, 234,
-	243, 247, 251, 256, 267, 274, 297,
+	2, 306, 71, 3, 2, 2, 2, 314, 309, 3, 2, 2, 2, 315, 314, 3, 2, 2, 2, 317,
+	315, 5, 310, 38, 2, 316, 317, 3, 2, 2, 2, 318, 315, 5, 312, 39, 2, 316,
+	318, 3, 2, 2, 2, 308, 316, 3, 2, 2, 2, 319, 311, 3, 2, 2, 2, 320, 319,
+	7, 53, 2, 2, 310, 320, 3, 2, 2, 2, 321, 313, 3, 2, 2, 2, 322, 321, 5, 20,
+	11, 2, 323, 322, 7, 52, 2, 2, 312, 323, 3, 2, 2, 2, 329, 325, 5, 308, 37,
+	2, 326, 329, 3, 2, 2, 2, 325, 327, 3, 2, 2, 2, 327, 324, 3, 2, 2, 2, 324,
+	326, 3, 2, 2, 2, 324, 328, 3, 2, 2, 2, 328, 85, 3, 2, 2, 2, 332, 315, 5,
+	330, 40, 2, 316, 332, 3, 2, 2, 2, 333, 331, 3, 2, 2, 2, 339, 335, 5, 42,
+	22, 2, 340, 339, 7, 6, 2, 2, 336, 340, 3, 2, 2, 2, 335, 337, 3, 2, 2, 2,
+	337, 334, 3, 2, 2, 2, 334, 336, 3, 2, 2, 2, 334, 338, 3, 2, 2, 2, 338,
+	333, 3, 2, 2, 2, 341, 334, 5, 42, 22, 2, 342, 341, 7, 54, 2, 2, 330, 342,
+	3, 2, 2, 2, 347, 109, 5, 343, 41, 2, 108, 347, 3, 2, 2, 2, 348, 109, 5,
+	345, 42, 2, 108, 348, 3, 2, 2, 2, 349, 344, 3, 2, 2, 2, 350, 349, 7, 55,
+	2, 2, 343, 350, 3, 2, 2, 2, 351, 346, 3, 2, 2, 2, 352, 351, 7, 56, 2, 2,
+	345, 352, 3, 2, 2, 2, 355, 315, 5, 353, 43, 2, 316, 355, 3, 2, 2, 2, 356,
+	354, 3, 2, 2, 2, 357, 356, 5, 42, 22, 2, 358, 357, 7, 57, 2, 2, 353, 358,
+	3, 2, 2, 2, 361, 109, 5, 359, 44, 2, 108, 361, 3, 2, 2, 2, 362, 360, 3,
+	2, 2, 2, 363, 362, 7, 58, 2, 2, 359, 363, 3, 2, 2, 2, 364, 366, 3, 2, 2,
+	2, 366, 367, 9, 9, 2, 2, 367, 365, 3, 2, 2, 2, 368, 279, 3, 2, 2, 2, 369,
+	278, 3, 2, 2, 2, 370, 368, 7, 48, 2, 2, 369, 370, 3, 2, 2, 2, 371, 368,
+	5, 364, 45, 2, 369, 371, 3, 2, 2, 2, 372, 265, 3, 2, 2, 2, 373, 264, 3,
+	2, 2, 2, 374, 372, 5, 364, 45, 2, 373, 374, 3, 2, 2, 2, 39, 75, 80, 83,
+	101, 108, 115, 117, 125, 129, 135, 145, 147, 156, 166, 168, 176, 180, 185,
+	191, 197, 201, 208, 215, 226, 234, 243, 247, 251, 256, 267, 274, 297, 316,
+	324, 334, 369, 373,
 }
 var literalNames = []string{
 	"", "'conc'", "'if'", "'else'", "','", "'@name'", "'@desc'", "'@id'", "",
 	"", "'&&'", "'||'", "", "", "", "", "", "", "", "", "'+'", "'-'", "'/'",
 	"'*'", "'=='", "'>'", "'<'", "'>='", "'<='", "'!='", "'!'", "':='", "'='",
 	"'+='", "'-='", "'*='", "'/='", "'['", "']'", "';'", "'{'", "'}'", "'('",
-	"')'", "'.'",
+	"')'", "'.'", "", "", "", "", "", "", "", "", "'halt'", "'exit'", "", "'rollback'",
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "NIL", "RULE", "AND", "OR", "TRUE", "FALSE",
@@ -169,7 +199,7 @@ var symbolicNames = []string{
 	"NOT", "ASSIGN", "SET", "PLUSEQUAL", "MINUSEQUAL", "MULTIEQUAL", "DIVEQUAL",
 	"LSQARE", "RSQARE", "SEMICOLON", "LR_BRACE", "RR_BRACE", "LR_BRACKET",
 	"RR_BRACKET", "DOT", "DQUOTA_STRING", "DOTTEDNAME", "REAL_LITERAL", "SL_COMMENT",
-	"WS",
+	"WS", "WHEN", "NO_LOOP", "AFTER", "HALT", "EXIT", "GROUP", "ROLLBACK",
 }
 
 var ruleNames = []string{
@@ -179,7 +209,8 @@ var ruleNames = []string{
 	"functionArgs", "integer", "realLiteral", "stringLiteral", "booleanLiteral",
 	"functionCall", "methodCall", "variable", "mathPmOperator", "mathMdOperator",
 	"comparisonOperator", "logicalOperator", "assignOperator", "notOperator",
-	"mapVar", "atName", "atDesc", "atId",
+	"mapVar", "atName", "atDesc", "atId", "ruleAttribute", "noLoop", "whenCondition",
+	"afterRules", "haltStmt", "exitStmt", "groupName", "rollbackStmt", "softKeyword",
 }
 
 type gengineParser struct {
@@ -263,6 +294,13 @@ const (
 	gengineParserREAL_LITERAL  = 47
 	gengineParserSL_COMMENT    = 48
 	gengineParserWS            = 49
+	gengineParserWHEN          = 50
+	gengineParserNO_LOOP       = 51
+	gengineParserAFTER         = 52
+	gengineParserHALT          = 53
+	gengineParserEXIT          = 54
+	gengineParserGROUP         = 55
+	gengineParserROLLBACK      = 56
 )
 
 // gengineParser rules.
@@ -302,6 +340,15 @@ const (
 	gengineParserRULE_atName             = 32
 	gengineParserRULE_atDesc             = 33
 	gengineParserRULE_atId               = 34
+	gengineParserRULE_ruleAttribute      = 35
+	gengineParserRULE_noLoop             = 36
+	gengineParserRULE_whenCondition      = 37
+	gengineParserRULE_afterRules         = 38
+	gengineParserRULE_haltStmt           = 39
+	gengineParserRULE_exitStmt           = 40
+	gengineParserRULE_groupName          = 41
+	gengineParserRULE_rollbackStmt       = 42
+	gengineParserRULE_softKeyword        = 43
 )
 
 // IPrimaryContext is an interface to support dynamic dispatch.
@@ -525,6 +572,29 @@ func (s *RuleEntityContext) Salience() ISalienceContext {
 	return t.(ISalienceContext)
 }
 
+func (s *RuleEntityContext) AllRuleAttribute() []IRuleAttributeContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IRuleAttributeContext)(nil)).Elem())
+	var tst = make([]IRuleAttributeContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IRuleAttributeContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *RuleEntityContext) RuleAttribute(i int) IRuleAttributeContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IRuleAttributeContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRuleAttributeContext)
+}
+
 func (s *RuleEntityContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -535,30 +605,1137 @@ func (s *RuleEntityContext) ToStringTree(ruleNames []string, recog antlr.Recogni
 
 func (s *RuleEntityContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.EnterRuleEntity(s)
+		listenerT.EnterRuleEntity(s)
+	}
+}
+
+func (s *RuleEntityContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitRuleEntity(s)
+	}
+}
+
+func (s *RuleEntityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitRuleEntity(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) RuleEntity() (localctx IRuleEntityContext) {
+	localctx = NewRuleEntityContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, gengineParserRULE_ruleEntity)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(75)
+		p.Match(gengineParserRULE)
+	}
+	{
+		p.SetState(76)
+		p.RuleName()
+	}
+	p.SetState(78)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == gengineParserDQUOTA_STRING {
+		{
+			p.SetState(77)
+			p.RuleDescription()
+		}
+
+	}
+	p.SetState(81)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == gengineParserSALIENCE {
+		{
+			p.SetState(80)
+			p.Salience()
+		}
+
+	}
+	p.SetState(322)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == gengineParserWHEN || _la == gengineParserNO_LOOP || _la == gengineParserAFTER || _la == gengineParserGROUP {
+		{
+			p.SetState(327)
+			p.RuleAttribute()
+		}
+
+		p.SetState(325)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(83)
+		p.Match(gengineParserBEGIN)
+	}
+	{
+		p.SetState(84)
+		p.RuleContent()
+	}
+	{
+		p.SetState(85)
+		p.Match(gengineParserEND)
+	}
+
+	return localctx
+}
+
+// IRuleNameContext is an interface to support dynamic dispatch.
+type IRuleNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsRuleNameContext differentiates from other interfaces.
+	IsRuleNameContext()
+}
+
+type RuleNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleNameContext() *RuleNameContext {
+	var p = new(RuleNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_ruleName
+	return p
+}
+
+func (*RuleNameContext) IsRuleNameContext() {}
+
+func NewRuleNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleNameContext {
+	var p = new(RuleNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_ruleName
+
+	return p
+}
+
+func (s *RuleNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleNameContext) StringLiteral() IStringLiteralContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *RuleNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterRuleName(s)
+	}
+}
+
+func (s *RuleNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitRuleName(s)
+	}
+}
+
+func (s *RuleNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitRuleName(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) RuleName() (localctx IRuleNameContext) {
+	localctx = NewRuleNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, gengineParserRULE_ruleName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(87)
+		p.StringLiteral()
+	}
+
+	return localctx
+}
+
+// IRuleDescriptionContext is an interface to support dynamic dispatch.
+type IRuleDescriptionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsRuleDescriptionContext differentiates from other interfaces.
+	IsRuleDescriptionContext()
+}
+
+type RuleDescriptionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleDescriptionContext() *RuleDescriptionContext {
+	var p = new(RuleDescriptionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_ruleDescription
+	return p
+}
+
+func (*RuleDescriptionContext) IsRuleDescriptionContext() {}
+
+func NewRuleDescriptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleDescriptionContext {
+	var p = new(RuleDescriptionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_ruleDescription
+
+	return p
+}
+
+func (s *RuleDescriptionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleDescriptionContext) StringLiteral() IStringLiteralContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *RuleDescriptionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleDescriptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleDescriptionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterRuleDescription(s)
+	}
+}
+
+func (s *RuleDescriptionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitRuleDescription(s)
+	}
+}
+
+func (s *RuleDescriptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitRuleDescription(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) RuleDescription() (localctx IRuleDescriptionContext) {
+	localctx = NewRuleDescriptionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, gengineParserRULE_ruleDescription)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(89)
+		p.StringLiteral()
+	}
+
+	return localctx
+}
+
+// ISalienceContext is an interface to support dynamic dispatch.
+type ISalienceContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsSalienceContext differentiates from other interfaces.
+	IsSalienceContext()
+}
+
+type SalienceContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptySalienceContext() *SalienceContext {
+	var p = new(SalienceContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_salience
+	return p
+}
+
+func (*SalienceContext) IsSalienceContext() {}
+
+func NewSalienceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SalienceContext {
+	var p = new(SalienceContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_salience
+
+	return p
+}
+
+func (s *SalienceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SalienceContext) SALIENCE() antlr.TerminalNode {
+	return s.GetToken(gengineParserSALIENCE, 0)
+}
+
+func (s *SalienceContext) Integer() IIntegerContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIntegerContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIntegerContext)
+}
+
+func (s *SalienceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SalienceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SalienceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterSalience(s)
+	}
+}
+
+func (s *SalienceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitSalience(s)
+	}
+}
+
+func (s *SalienceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitSalience(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) Salience() (localctx ISalienceContext) {
+	localctx = NewSalienceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, gengineParserRULE_salience)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(91)
+		p.Match(gengineParserSALIENCE)
+	}
+	{
+		p.SetState(92)
+		p.Integer()
+	}
+
+	return localctx
+}
+
+// IRuleAttributeContext is an interface to support dynamic dispatch.
+type IRuleAttributeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsRuleAttributeContext differentiates from other interfaces.
+	IsRuleAttributeContext()
+}
+
+type RuleAttributeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleAttributeContext() *RuleAttributeContext {
+	var p = new(RuleAttributeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_ruleAttribute
+	return p
+}
+
+func (*RuleAttributeContext) IsRuleAttributeContext() {}
+
+func NewRuleAttributeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleAttributeContext {
+	var p = new(RuleAttributeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_ruleAttribute
+
+	return p
+}
+
+func (s *RuleAttributeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleAttributeContext) NoLoop() INoLoopContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*INoLoopContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INoLoopContext)
+}
+
+func (s *RuleAttributeContext) WhenCondition() IWhenConditionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IWhenConditionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IWhenConditionContext)
+}
+
+func (s *RuleAttributeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleAttributeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleAttributeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterRuleAttribute(s)
+	}
+}
+
+func (s *RuleAttributeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitRuleAttribute(s)
+	}
+}
+
+func (s *RuleAttributeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitRuleAttribute(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) RuleAttribute() (localctx IRuleAttributeContext) {
+	localctx = NewRuleAttributeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 306, gengineParserRULE_ruleAttribute)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(314)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case gengineParserNO_LOOP:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(315)
+			p.NoLoop()
+		}
+
+	case gengineParserWHEN:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(316)
+			p.WhenCondition()
+		}
+
+	case gengineParserAFTER:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(330)
+			p.AfterRules()
+		}
+
+	case gengineParserGROUP:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(353)
+			p.GroupName()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// INoLoopContext is an interface to support dynamic dispatch.
+type INoLoopContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsNoLoopContext differentiates from other interfaces.
+	IsNoLoopContext()
+}
+
+type NoLoopContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyNoLoopContext() *NoLoopContext {
+	var p = new(NoLoopContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_noLoop
+	return p
+}
+
+func (*NoLoopContext) IsNoLoopContext() {}
+
+func NewNoLoopContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *NoLoopContext {
+	var p = new(NoLoopContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_noLoop
+
+	return p
+}
+
+func (s *NoLoopContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *NoLoopContext) NO_LOOP() antlr.TerminalNode {
+	return s.GetToken(gengineParserNO_LOOP, 0)
+}
+
+func (s *NoLoopContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NoLoopContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *NoLoopContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterNoLoop(s)
+	}
+}
+
+func (s *NoLoopContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitNoLoop(s)
+	}
+}
+
+func (s *NoLoopContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitNoLoop(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) NoLoop() (localctx INoLoopContext) {
+	localctx = NewNoLoopContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 308, gengineParserRULE_noLoop)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(318)
+		p.Match(gengineParserNO_LOOP)
+	}
+
+	return localctx
+}
+
+// IWhenConditionContext is an interface to support dynamic dispatch.
+type IWhenConditionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsWhenConditionContext differentiates from other interfaces.
+	IsWhenConditionContext()
+}
+
+type WhenConditionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyWhenConditionContext() *WhenConditionContext {
+	var p = new(WhenConditionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_whenCondition
+	return p
+}
+
+func (*WhenConditionContext) IsWhenConditionContext() {}
+
+func NewWhenConditionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *WhenConditionContext {
+	var p = new(WhenConditionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_whenCondition
+
+	return p
+}
+
+func (s *WhenConditionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *WhenConditionContext) WHEN() antlr.TerminalNode {
+	return s.GetToken(gengineParserWHEN, 0)
+}
+
+func (s *WhenConditionContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *WhenConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *WhenConditionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *WhenConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterWhenCondition(s)
+	}
+}
+
+func (s *WhenConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitWhenCondition(s)
+	}
+}
+
+func (s *WhenConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitWhenCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) WhenCondition() (localctx IWhenConditionContext) {
+	localctx = NewWhenConditionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 310, gengineParserRULE_whenCondition)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(321)
+		p.Match(gengineParserWHEN)
+	}
+	{
+		p.SetState(320)
+		p.expression(0)
+	}
+
+	return localctx
+}
+
+// IAfterRulesContext is an interface to support dynamic dispatch.
+type IAfterRulesContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAfterRulesContext differentiates from other interfaces.
+	IsAfterRulesContext()
+}
+
+type AfterRulesContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAfterRulesContext() *AfterRulesContext {
+	var p = new(AfterRulesContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_afterRules
+	return p
+}
+
+func (*AfterRulesContext) IsAfterRulesContext() {}
+
+func NewAfterRulesContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AfterRulesContext {
+	var p = new(AfterRulesContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_afterRules
+
+	return p
+}
+
+func (s *AfterRulesContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AfterRulesContext) AFTER() antlr.TerminalNode {
+	return s.GetToken(gengineParserAFTER, 0)
+}
+
+func (s *AfterRulesContext) AllStringLiteral() []IStringLiteralContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem())
+	var tst = make([]IStringLiteralContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IStringLiteralContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *AfterRulesContext) StringLiteral(i int) IStringLiteralContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *AfterRulesContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AfterRulesContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *AfterRulesContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterAfterRules(s)
+	}
+}
+
+func (s *AfterRulesContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitAfterRules(s)
+	}
+}
+
+func (s *AfterRulesContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitAfterRules(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) AfterRules() (localctx IAfterRulesContext) {
+	localctx = NewAfterRulesContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 328, gengineParserRULE_afterRules)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(340)
+		p.Match(gengineParserAFTER)
+	}
+	{
+		p.SetState(339)
+		p.StringLiteral()
+	}
+	p.SetState(332)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == gengineParserT__3 {
+		{
+			p.SetState(338)
+			p.Match(gengineParserT__3)
+		}
+		{
+			p.SetState(337)
+			p.StringLiteral()
+		}
+
+		p.SetState(335)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IGroupNameContext is an interface to support dynamic dispatch.
+type IGroupNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsGroupNameContext differentiates from other interfaces.
+	IsGroupNameContext()
+}
+
+type GroupNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyGroupNameContext() *GroupNameContext {
+	var p = new(GroupNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_groupName
+	return p
+}
+
+func (*GroupNameContext) IsGroupNameContext() {}
+
+func NewGroupNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *GroupNameContext {
+	var p = new(GroupNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_groupName
+
+	return p
+}
+
+func (s *GroupNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *GroupNameContext) GROUP() antlr.TerminalNode {
+	return s.GetToken(gengineParserGROUP, 0)
+}
+
+func (s *GroupNameContext) StringLiteral() IStringLiteralContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *GroupNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *GroupNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *GroupNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterGroupName(s)
+	}
+}
+
+func (s *GroupNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.ExitGroupName(s)
+	}
+}
+
+func (s *GroupNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case gengineVisitor:
+		return t.VisitGroupName(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *gengineParser) GroupName() (localctx IGroupNameContext) {
+	localctx = NewGroupNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 351, gengineParserRULE_groupName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(356)
+		p.Match(gengineParserGROUP)
+	}
+	{
+		p.SetState(355)
+		p.StringLiteral()
+	}
+
+	return localctx
+}
+
+// IRollbackStmtContext is an interface to support dynamic dispatch.
+type IRollbackStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsRollbackStmtContext differentiates from other interfaces.
+	IsRollbackStmtContext()
+}
+
+type RollbackStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRollbackStmtContext() *RollbackStmtContext {
+	var p = new(RollbackStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = gengineParserRULE_rollbackStmt
+	return p
+}
+
+func (*RollbackStmtContext) IsRollbackStmtContext() {}
+
+func NewRollbackStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RollbackStmtContext {
+	var p = new(RollbackStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = gengineParserRULE_rollbackStmt
+
+	return p
+}
+
+func (s *RollbackStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RollbackStmtContext) ROLLBACK() antlr.TerminalNode {
+	return s.GetToken(gengineParserROLLBACK, 0)
+}
+
+func (s *RollbackStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RollbackStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RollbackStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(gengineListener); ok {
+		listenerT.EnterRollbackStmt(s)
 	}
 }
 
-func (s *RuleEntityContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *RollbackStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.ExitRuleEntity(s)
+		listenerT.ExitRollbackStmt(s)
 	}
 }
 
-func (s *RuleEntityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *RollbackStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case gengineVisitor:
-		return t.VisitRuleEntity(s)
+		return t.VisitRollbackStmt(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *gengineParser) RuleEntity() (localctx IRuleEntityContext) {
-	localctx = NewRuleEntityContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 2, gengineParserRULE_ruleEntity)
-	var _la int
+func (p *gengineParser) RollbackStmt() (localctx IRollbackStmtContext) {
+	localctx = NewRollbackStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 357, gengineParserRULE_rollbackStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -578,132 +1755,109 @@ func (p *gengineParser) RuleEntity() (localctx IRuleEntityContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(75)
-		p.Match(gengineParserRULE)
-	}
-	{
-		p.SetState(76)
-		p.RuleName()
-	}
-	p.SetState(78)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == gengineParserDQUOTA_STRING {
-		{
-			p.SetState(77)
-			p.RuleDescription()
-		}
-
-	}
-	p.SetState(81)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == gengineParserSALIENCE {
-		{
-			p.SetState(80)
-			p.Salience()
-		}
-
-	}
-	{
-		p.SetState(83)
-		p.Match(gengineParserBEGIN)
-	}
-	{
-		p.SetState(84)
-		p.RuleContent()
-	}
-	{
-		p.SetState(85)
-		p.Match(gengineParserEND)
+		p.SetState(361)
+		p.Match(gengineParserROLLBACK)
 	}
 
 	return localctx
 }
 
-// IRuleNameContext is an interface to support dynamic dispatch.
-type IRuleNameContext interface {
+// ISoftKeywordContext is an interface to support dynamic dispatch.
+type ISoftKeywordContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsRuleNameContext differentiates from other interfaces.
-	IsRuleNameContext()
+	// IsSoftKeywordContext differentiates from other interfaces.
+	IsSoftKeywordContext()
 }
 
-type RuleNameContext struct {
+type SoftKeywordContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyRuleNameContext() *RuleNameContext {
-	var p = new(RuleNameContext)
+func NewEmptySoftKeywordContext() *SoftKeywordContext {
+	var p = new(SoftKeywordContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = gengineParserRULE_ruleName
+	p.RuleIndex = gengineParserRULE_softKeyword
 	return p
 }
 
-func (*RuleNameContext) IsRuleNameContext() {}
+func (*SoftKeywordContext) IsSoftKeywordContext() {}
 
-func NewRuleNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleNameContext {
-	var p = new(RuleNameContext)
+func NewSoftKeywordContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SoftKeywordContext {
+	var p = new(SoftKeywordContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = gengineParserRULE_ruleName
+	p.RuleIndex = gengineParserRULE_softKeyword
 
 	return p
 }
 
-func (s *RuleNameContext) GetParser() antlr.Parser { return s.parser }
+func (s *SoftKeywordContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *RuleNameContext) StringLiteral() IStringLiteralContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), 0)
+func (s *SoftKeywordContext) WHEN() antlr.TerminalNode {
+	return s.GetToken(gengineParserWHEN, 0)
+}
 
-	if t == nil {
-		return nil
-	}
+func (s *SoftKeywordContext) AFTER() antlr.TerminalNode {
+	return s.GetToken(gengineParserAFTER, 0)
+}
 
-	return t.(IStringLiteralContext)
+func (s *SoftKeywordContext) HALT() antlr.TerminalNode {
+	return s.GetToken(gengineParserHALT, 0)
 }
 
-func (s *RuleNameContext) GetRuleContext() antlr.RuleContext {
+func (s *SoftKeywordContext) EXIT() antlr.TerminalNode {
+	return s.GetToken(gengineParserEXIT, 0)
+}
+
+func (s *SoftKeywordContext) GROUP() antlr.TerminalNode {
+	return s.GetToken(gengineParserGROUP, 0)
+}
+
+func (s *SoftKeywordContext) ROLLBACK() antlr.TerminalNode {
+	return s.GetToken(gengineParserROLLBACK, 0)
+}
+
+func (s *SoftKeywordContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *RuleNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SoftKeywordContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *RuleNameContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SoftKeywordContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.EnterRuleName(s)
+		listenerT.EnterSoftKeyword(s)
 	}
 }
 
-func (s *RuleNameContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SoftKeywordContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.ExitRuleName(s)
+		listenerT.ExitSoftKeyword(s)
 	}
 }
 
-func (s *RuleNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *SoftKeywordContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case gengineVisitor:
-		return t.VisitRuleName(s)
+		return t.VisitSoftKeyword(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *gengineParser) RuleName() (localctx IRuleNameContext) {
-	localctx = NewRuleNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, gengineParserRULE_ruleName)
+func (p *gengineParser) SoftKeyword() (localctx ISoftKeywordContext) {
+	localctx = NewSoftKeywordContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 362, gengineParserRULE_softKeyword)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -723,94 +1877,95 @@ func (p *gengineParser) RuleName() (localctx IRuleNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(87)
-		p.StringLiteral()
+		p.SetState(364)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(((_la-50)&-(0x1f+1)) == 0 && ((1<<uint((_la-50)))&((1<<(gengineParserWHEN-50))|(1<<(gengineParserAFTER-50))|(1<<(gengineParserHALT-50))|(1<<(gengineParserEXIT-50))|(1<<(gengineParserGROUP-50))|(1<<(gengineParserROLLBACK-50)))) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
 }
 
-// IRuleDescriptionContext is an interface to support dynamic dispatch.
-type IRuleDescriptionContext interface {
+// IHaltStmtContext is an interface to support dynamic dispatch.
+type IHaltStmtContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsRuleDescriptionContext differentiates from other interfaces.
-	IsRuleDescriptionContext()
+	// IsHaltStmtContext differentiates from other interfaces.
+	IsHaltStmtContext()
 }
 
-type RuleDescriptionContext struct {
+type HaltStmtContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyRuleDescriptionContext() *RuleDescriptionContext {
-	var p = new(RuleDescriptionContext)
+func NewEmptyHaltStmtContext() *HaltStmtContext {
+	var p = new(HaltStmtContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = gengineParserRULE_ruleDescription
+	p.RuleIndex = gengineParserRULE_haltStmt
 	return p
 }
 
-func (*RuleDescriptionContext) IsRuleDescriptionContext() {}
+func (*HaltStmtContext) IsHaltStmtContext() {}
 
-func NewRuleDescriptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleDescriptionContext {
-	var p = new(RuleDescriptionContext)
+func NewHaltStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *HaltStmtContext {
+	var p = new(HaltStmtContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = gengineParserRULE_ruleDescription
+	p.RuleIndex = gengineParserRULE_haltStmt
 
 	return p
 }
 
-func (s *RuleDescriptionContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *RuleDescriptionContext) StringLiteral() IStringLiteralContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringLiteralContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
+func (s *HaltStmtContext) GetParser() antlr.Parser { return s.parser }
 
-	return t.(IStringLiteralContext)
+func (s *HaltStmtContext) HALT() antlr.TerminalNode {
+	return s.GetToken(gengineParserHALT, 0)
 }
 
-func (s *RuleDescriptionContext) GetRuleContext() antlr.RuleContext {
+func (s *HaltStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *RuleDescriptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *HaltStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *RuleDescriptionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *HaltStmtContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.EnterRuleDescription(s)
+		listenerT.EnterHaltStmt(s)
 	}
 }
 
-func (s *RuleDescriptionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *HaltStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.ExitRuleDescription(s)
+		listenerT.ExitHaltStmt(s)
 	}
 }
 
-func (s *RuleDescriptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *HaltStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case gengineVisitor:
-		return t.VisitRuleDescription(s)
+		return t.VisitHaltStmt(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *gengineParser) RuleDescription() (localctx IRuleDescriptionContext) {
-	localctx = NewRuleDescriptionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, gengineParserRULE_ruleDescription)
+func (p *gengineParser) HaltStmt() (localctx IHaltStmtContext) {
+	localctx = NewHaltStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 341, gengineParserRULE_haltStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -830,98 +1985,88 @@ func (p *gengineParser) RuleDescription() (localctx IRuleDescriptionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(89)
-		p.StringLiteral()
+		p.SetState(348)
+		p.Match(gengineParserHALT)
 	}
 
 	return localctx
 }
 
-// ISalienceContext is an interface to support dynamic dispatch.
-type ISalienceContext interface {
+// IExitStmtContext is an interface to support dynamic dispatch.
+type IExitStmtContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSalienceContext differentiates from other interfaces.
-	IsSalienceContext()
+	// IsExitStmtContext differentiates from other interfaces.
+	IsExitStmtContext()
 }
 
-type SalienceContext struct {
+type ExitStmtContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySalienceContext() *SalienceContext {
-	var p = new(SalienceContext)
+func NewEmptyExitStmtContext() *ExitStmtContext {
+	var p = new(ExitStmtContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = gengineParserRULE_salience
+	p.RuleIndex = gengineParserRULE_exitStmt
 	return p
 }
 
-func (*SalienceContext) IsSalienceContext() {}
+func (*ExitStmtContext) IsExitStmtContext() {}
 
-func NewSalienceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SalienceContext {
-	var p = new(SalienceContext)
+func NewExitStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExitStmtContext {
+	var p = new(ExitStmtContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = gengineParserRULE_salience
+	p.RuleIndex = gengineParserRULE_exitStmt
 
 	return p
 }
 
-func (s *SalienceContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SalienceContext) SALIENCE() antlr.TerminalNode {
-	return s.GetToken(gengineParserSALIENCE, 0)
-}
-
-func (s *SalienceContext) Integer() IIntegerContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIntegerContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
+func (s *ExitStmtContext) GetParser() antlr.Parser { return s.parser }
 
-	return t.(IIntegerContext)
+func (s *ExitStmtContext) EXIT() antlr.TerminalNode {
+	return s.GetToken(gengineParserEXIT, 0)
 }
 
-func (s *SalienceContext) GetRuleContext() antlr.RuleContext {
+func (s *ExitStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SalienceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ExitStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SalienceContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExitStmtContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.EnterSalience(s)
+		listenerT.EnterExitStmt(s)
 	}
 }
 
-func (s *SalienceContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExitStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(gengineListener); ok {
-		listenerT.ExitSalience(s)
+		listenerT.ExitExitStmt(s)
 	}
 }
 
-func (s *SalienceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ExitStmtContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case gengineVisitor:
-		return t.VisitSalience(s)
+		return t.VisitExitStmt(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *gengineParser) Salience() (localctx ISalienceContext) {
-	localctx = NewSalienceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, gengineParserRULE_salience)
+func (p *gengineParser) ExitStmt() (localctx IExitStmtContext) {
+	localctx = NewExitStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 343, gengineParserRULE_exitStmt)
 
 	defer func() {
 		p.ExitRule()
@@ -941,12 +2086,8 @@ func (p *gengineParser) Salience() (localctx ISalienceContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
-		p.Match(gengineParserSALIENCE)
-	}
-	{
-		p.SetState(92)
-		p.Integer()
+		p.SetState(350)
+		p.Match(gengineParserEXIT)
 	}
 
 	return localctx
@@ -1176,7 +2317,7 @@ func (p *gengineParser) Statements() (localctx IStatementsContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || _la == gengineParserDOTTEDNAME {
+	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || (((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(gengineParserDOTTEDNAME-46))|(1<<(gengineParserWHEN-46))|(1<<(gengineParserAFTER-46))|(1<<(gengineParserHALT-46))|(1<<(gengineParserEXIT-46))|(1<<(gengineParserGROUP-46))|(1<<(gengineParserROLLBACK-46)))) != 0) {
 		{
 			p.SetState(96)
 			p.Statement()
@@ -1278,6 +2419,36 @@ func (s *StatementContext) ConcStatement() IConcStatementContext {
 	return t.(IConcStatementContext)
 }
 
+func (s *StatementContext) HaltStmt() IHaltStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IHaltStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IHaltStmtContext)
+}
+
+func (s *StatementContext) ExitStmt() IExitStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExitStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExitStmtContext)
+}
+
+func (s *StatementContext) RollbackStmt() IRollbackStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IRollbackStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRollbackStmtContext)
+}
+
 func (s *StatementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -1366,6 +2537,27 @@ func (p *gengineParser) Statement() (localctx IStatementContext) {
 			p.ConcStatement()
 		}
 
+	case 6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(345)
+			p.HaltStmt()
+		}
+
+	case 7:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(346)
+			p.ExitStmt()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(359)
+			p.RollbackStmt()
+		}
+
 	}
 
 	return localctx
@@ -1550,7 +2742,7 @@ func (p *gengineParser) ConcStatement() (localctx IConcStatementContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == gengineParserSIMPLENAME || _la == gengineParserDOTTEDNAME {
+	for _la == gengineParserSIMPLENAME || (((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(gengineParserDOTTEDNAME-46))|(1<<(gengineParserWHEN-46))|(1<<(gengineParserAFTER-46))|(1<<(gengineParserHALT-46))|(1<<(gengineParserEXIT-46))|(1<<(gengineParserGROUP-46))|(1<<(gengineParserROLLBACK-46)))) != 0) {
 		p.SetState(113)
 		p.GetErrorHandler().Sync(p)
 		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext()) {
@@ -2044,7 +3236,7 @@ func (p *gengineParser) mathExpression(_p int) (localctx IMathExpressionContext)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
-	case gengineParserT__4, gengineParserT__5, gengineParserT__6, gengineParserTRUE, gengineParserFALSE, gengineParserSIMPLENAME, gengineParserINT, gengineParserMINUS, gengineParserDQUOTA_STRING, gengineParserDOTTEDNAME, gengineParserREAL_LITERAL:
+	case gengineParserT__4, gengineParserT__5, gengineParserT__6, gengineParserTRUE, gengineParserFALSE, gengineParserSIMPLENAME, gengineParserINT, gengineParserMINUS, gengineParserDQUOTA_STRING, gengineParserDOTTEDNAME, gengineParserREAL_LITERAL, gengineParserWHEN, gengineParserAFTER, gengineParserHALT, gengineParserEXIT, gengineParserGROUP, gengineParserROLLBACK:
 		{
 			p.SetState(149)
 			p.ExpressionAtom()
@@ -2655,7 +3847,7 @@ func (p *gengineParser) IfStmt() (localctx IIfStmtContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || _la == gengineParserDOTTEDNAME {
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || (((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(gengineParserDOTTEDNAME-46))|(1<<(gengineParserWHEN-46))|(1<<(gengineParserAFTER-46))|(1<<(gengineParserHALT-46))|(1<<(gengineParserEXIT-46))|(1<<(gengineParserGROUP-46))|(1<<(gengineParserROLLBACK-46)))) != 0) {
 		{
 			p.SetState(188)
 			p.Statements()
@@ -2835,7 +4027,7 @@ func (p *gengineParser) ElseIfStmt() (localctx IElseIfStmtContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || _la == gengineParserDOTTEDNAME {
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || (((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(gengineParserDOTTEDNAME-46))|(1<<(gengineParserWHEN-46))|(1<<(gengineParserAFTER-46))|(1<<(gengineParserHALT-46))|(1<<(gengineParserEXIT-46))|(1<<(gengineParserGROUP-46))|(1<<(gengineParserROLLBACK-46)))) != 0) {
 		{
 			p.SetState(205)
 			p.Statements()
@@ -2970,7 +4162,7 @@ func (p *gengineParser) ElseStmt() (localctx IElseStmtContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || _la == gengineParserDOTTEDNAME {
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__0)|(1<<gengineParserT__1)|(1<<gengineParserSIMPLENAME))) != 0) || (((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(gengineParserDOTTEDNAME-46))|(1<<(gengineParserWHEN-46))|(1<<(gengineParserAFTER-46))|(1<<(gengineParserHALT-46))|(1<<(gengineParserEXIT-46))|(1<<(gengineParserGROUP-46))|(1<<(gengineParserROLLBACK-46)))) != 0) {
 		{
 			p.SetState(212)
 			p.Statements()
@@ -4034,6 +5226,16 @@ func (s *FunctionCallContext) FunctionArgs() IFunctionArgsContext {
 	return t.(IFunctionArgsContext)
 }
 
+func (s *FunctionCallContext) SoftKeyword() ISoftKeywordContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISoftKeywordContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISoftKeywordContext)
+}
+
 func (s *FunctionCallContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4086,9 +5288,24 @@ func (p *gengineParser) FunctionCall() (localctx IFunctionCallContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(262)
-		p.Match(gengineParserSIMPLENAME)
+	p.SetState(371)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case gengineParserSIMPLENAME:
+		{
+			p.SetState(262)
+			p.Match(gengineParserSIMPLENAME)
+		}
+
+	case gengineParserWHEN, gengineParserAFTER, gengineParserHALT, gengineParserEXIT, gengineParserGROUP, gengineParserROLLBACK:
+		{
+			p.SetState(372)
+			p.SoftKeyword()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	{
 		p.SetState(263)
@@ -4098,7 +5315,7 @@ func (p *gengineParser) FunctionCall() (localctx IFunctionCallContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__4)|(1<<gengineParserT__5)|(1<<gengineParserT__6)|(1<<gengineParserTRUE)|(1<<gengineParserFALSE)|(1<<gengineParserSIMPLENAME)|(1<<gengineParserINT)|(1<<gengineParserMINUS)|(1<<gengineParserNOT))) != 0) || (((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(gengineParserLR_BRACKET-42))|(1<<(gengineParserDQUOTA_STRING-42))|(1<<(gengineParserDOTTEDNAME-42))|(1<<(gengineParserREAL_LITERAL-42)))) != 0) {
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__4)|(1<<gengineParserT__5)|(1<<gengineParserT__6)|(1<<gengineParserTRUE)|(1<<gengineParserFALSE)|(1<<gengineParserSIMPLENAME)|(1<<gengineParserINT)|(1<<gengineParserMINUS)|(1<<gengineParserNOT))) != 0) || (((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(gengineParserLR_BRACKET-42))|(1<<(gengineParserDQUOTA_STRING-42))|(1<<(gengineParserDOTTEDNAME-42))|(1<<(gengineParserREAL_LITERAL-42))|(1<<(gengineParserWHEN-42))|(1<<(gengineParserAFTER-42))|(1<<(gengineParserHALT-42))|(1<<(gengineParserEXIT-42))|(1<<(gengineParserGROUP-42))|(1<<(gengineParserROLLBACK-42)))) != 0) {
 		{
 			p.SetState(264)
 			p.FunctionArgs()
@@ -4237,7 +5454,7 @@ func (p *gengineParser) MethodCall() (localctx IMethodCallContext) {
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__4)|(1<<gengineParserT__5)|(1<<gengineParserT__6)|(1<<gengineParserTRUE)|(1<<gengineParserFALSE)|(1<<gengineParserSIMPLENAME)|(1<<gengineParserINT)|(1<<gengineParserMINUS)|(1<<gengineParserNOT))) != 0) || (((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(gengineParserLR_BRACKET-42))|(1<<(gengineParserDQUOTA_STRING-42))|(1<<(gengineParserDOTTEDNAME-42))|(1<<(gengineParserREAL_LITERAL-42)))) != 0) {
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<gengineParserT__4)|(1<<gengineParserT__5)|(1<<gengineParserT__6)|(1<<gengineParserTRUE)|(1<<gengineParserFALSE)|(1<<gengineParserSIMPLENAME)|(1<<gengineParserINT)|(1<<gengineParserMINUS)|(1<<gengineParserNOT))) != 0) || (((_la-42)&-(0x1f+1)) == 0 && ((1<<uint((_la-42)))&((1<<(gengineParserLR_BRACKET-42))|(1<<(gengineParserDQUOTA_STRING-42))|(1<<(gengineParserDOTTEDNAME-42))|(1<<(gengineParserREAL_LITERAL-42))|(1<<(gengineParserWHEN-42))|(1<<(gengineParserAFTER-42))|(1<<(gengineParserHALT-42))|(1<<(gengineParserEXIT-42))|(1<<(gengineParserGROUP-42))|(1<<(gengineParserROLLBACK-42)))) != 0) {
 		{
 			p.SetState(271)
 			p.FunctionArgs()
@@ -4298,6 +5515,16 @@ func (s *VariableContext) DOTTEDNAME() antlr.TerminalNode {
 	return s.GetToken(gengineParserDOTTEDNAME, 0)
 }
 
+func (s *VariableContext) SoftKeyword() ISoftKeywordContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISoftKeywordContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISoftKeywordContext)
+}
+
 func (s *VariableContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4331,7 +5558,6 @@ func (s *VariableContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 func (p *gengineParser) Variable() (localctx IVariableContext) {
 	localctx = NewVariableContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 48, gengineParserRULE_variable)
-	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -4349,17 +5575,33 @@ func (p *gengineParser) Variable() (localctx IVariableContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(276)
-		_la = p.GetTokenStream().LA(1)
+	p.SetState(367)
+	p.GetErrorHandler().Sync(p)
 
-		if !(_la == gengineParserSIMPLENAME || _la == gengineParserDOTTEDNAME) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+	switch p.GetTokenStream().LA(1) {
+	case gengineParserSIMPLENAME:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(276)
+			p.Match(gengineParserSIMPLENAME)
+		}
+
+	case gengineParserDOTTEDNAME:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(368)
+			p.Match(gengineParserDOTTEDNAME)
+		}
+
+	case gengineParserWHEN, gengineParserAFTER, gengineParserHALT, gengineParserEXIT, gengineParserGROUP, gengineParserROLLBACK:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(369)
+			p.SoftKeyword()
 		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -5227,7 +6469,7 @@ func (p *gengineParser) MapVar() (localctx IMapVarContext) {
 			p.StringLiteral()
 		}
 
-	case gengineParserSIMPLENAME, gengineParserDOTTEDNAME:
+	case gengineParserSIMPLENAME, gengineParserDOTTEDNAME, gengineParserWHEN, gengineParserAFTER, gengineParserHALT, gengineParserEXIT, gengineParserGROUP, gengineParserROLLBACK:
 		{
 			p.SetState(294)
 			p.Variable()
@@ -23,6 +23,33 @@ type gengineVisitor interface {
 	// Visit a parse tree produced by gengineParser#salience.
 	VisitSalience(ctx *SalienceContext) interface{}
 
+	// Visit a parse tree produced by gengineParser#ruleAttribute.
+	VisitRuleAttribute(ctx *RuleAttributeContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#noLoop.
+	VisitNoLoop(ctx *NoLoopContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#whenCondition.
+	VisitWhenCondition(ctx *WhenConditionContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#afterRules.
+	VisitAfterRules(ctx *AfterRulesContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#groupName.
+	VisitGroupName(ctx *GroupNameContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#rollbackStmt.
+	VisitRollbackStmt(ctx *RollbackStmtContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#softKeyword.
+	VisitSoftKeyword(ctx *SoftKeywordContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#haltStmt.
+	VisitHaltStmt(ctx *HaltStmtContext) interface{}
+
+	// Visit a parse tree produced by gengineParser#exitStmt.
+	VisitExitStmt(ctx *ExitStmtContext) interface{}
+
 	// Visit a parse tree produced by gengineParser#ruleContent.
 	VisitRuleContent(ctx *RuleContentContext) interface{}
 
@@ -2,14 +2,14 @@ grammar gengine;
 
 primary: ruleEntity+;
 
-ruleEntity:  RULE ruleName ruleDescription? salience? BEGIN ruleContent END;
+ruleEntity:  RULE ruleName ruleDescription? salience? ruleAttribute* BEGIN ruleContent END;
 ruleName : stringLiteral;
 ruleDescription : stringLiteral;
 salience : SALIENCE integer;
 ruleContent : statements;
 statements: statement+;
 
-statement : ifStmt | methodCall  | functionCall | assignment | concStatement ;
+statement : ifStmt | methodCall  | functionCall | assignment | concStatement | haltStmt | exitStmt | rollbackStmt ;
 
 concStatement : 'conc' '{' ( methodCall | functionCall | assignment )* '}';
 
@@ -64,11 +64,11 @@ stringLiteral: DQUOTA_STRING ;
 
 booleanLiteral : TRUE | FALSE;
 
-functionCall : SIMPLENAME '(' functionArgs? ')';
+functionCall : (SIMPLENAME | softKeyword) '(' functionArgs? ')';
 
 methodCall : DOTTEDNAME '(' functionArgs? ')';
 
-variable :  SIMPLENAME | DOTTEDNAME ;
+variable :  SIMPLENAME | DOTTEDNAME | softKeyword ;
 
 mathPmOperator : PLUS | MINUS ;
 
@@ -88,6 +88,16 @@ atName : '@name';
 atDesc : '@desc';
 atId : '@id';
 
+ruleAttribute : noLoop | whenCondition | afterRules | groupName;
+noLoop : NO_LOOP;
+whenCondition : WHEN expression;
+afterRules : AFTER stringLiteral (',' stringLiteral)*;
+groupName : GROUP stringLiteral;
+haltStmt : HALT;
+exitStmt : EXIT;
+rollbackStmt : ROLLBACK;
+softKeyword : WHEN | AFTER | HALT | EXIT | GROUP | ROLLBACK;
+
 fragment DEC_DIGIT          : [0-9];
 fragment A                  : [aA] ;
 fragment B                  : [bB] ;
@@ -128,6 +138,13 @@ NULL_LITERAL                : N U L L ;
 SALIENCE                    : S A L I E N C E ;
 BEGIN                       : B E G I N;
 END                         : E N D;
+WHEN                        : W H E N;
+NO_LOOP                     : N O '-' L O O P;
+AFTER                       : A F T E R;
+HALT                        : 'halt';
+EXIT                        : 'exit';
+GROUP                       : G R O U P;
+ROLLBACK                    : 'rollback';
 
 SIMPLENAME :  ('a'..'z' |'A'..'Z'| '_')+ ( ('0'..'9') | ('a'..'z' |'A'..'Z') | '_' )* ;
 
@@ -1,6 +1,7 @@
 package iparser
 
 import (
+	"gengine/internal/core/errors"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"strconv"
 )
@@ -8,6 +9,7 @@ import (
 type GengineErrorListener struct {
 	antlr.ErrorListener
 	GrammarErrors []string
+	Errors        []error // the same as GrammarErrors, every one is a *errors.RuleError
 }
 
 func NewGengineErrorListener() *GengineErrorListener {
@@ -21,6 +23,7 @@ syntax err check
 */
 func (el *GengineErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
 	el.GrammarErrors = append(el.GrammarErrors, "line"+" "+strconv.Itoa(line)+":"+strconv.Itoa(column)+" "+msg)
+	el.Errors = append(el.Errors, &errors.RuleError{LineNum: line, Column: column, Kind: errors.KindParse, Cause: errors.New(msg)})
 }
 
 func (el *GengineErrorListener) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
@@ -3,6 +3,7 @@ package iparser
 import (
 	"fmt"
 	"gengine/internal/base"
+	"gengine/internal/core"
 	"gengine/internal/core/errors"
 	parser "gengine/internal/iantlr/alr"
 	"reflect"
@@ -24,15 +25,25 @@ func NewGengineParserListener(ctx *base.KnowledgeContext) *GengineParserListener
 type GengineParserListener struct {
 	parser.BasegengineListener
 	ParseErrors []string
+	Errors      []error // the same as ParseErrors, every one is a *errors.RuleError
 
 	KnowledgeContext *base.KnowledgeContext
 	Stack            *stack.Stack
 	ruleName         string
 	ruleDescription  string
+
+	// decimal mode of all rules, and the rules which override it
+	DecimalMode  bool
+	DecimalRules map[string]bool
+	decimal      bool //decimal mode of current rule
+
+	// what to do when integer arithmetic overflows, see core.OverflowWrap
+	Overflow int
 }
 
 func (g *GengineParserListener) AddError(e error) {
 	g.ParseErrors = append(g.ParseErrors, e.Error())
+	g.Errors = append(g.Errors, &errors.RuleError{RuleName: g.ruleName, Kind: errors.KindParse, Cause: e})
 }
 
 func (g *GengineParserListener) VisitTerminal(node antlr.TerminalNode) {}
@@ -66,6 +77,7 @@ func (g *GengineParserListener) ExitRuleEntity(ctx *parser.RuleEntityContext) {
 		g.AddError(errors.New(fmt.Sprintf("already existed entity's name \"%s\"", entity.RuleName)))
 		return
 	}
+	entity.Order = len(g.KnowledgeContext.RuleEntities)
 	g.KnowledgeContext.RuleEntities[entity.RuleName] = entity
 }
 
@@ -84,6 +96,11 @@ func (g *GengineParserListener) ExitRuleName(ctx *parser.RuleNameContext) {
 	entity := g.Stack.Peek().(*base.RuleEntity)
 	g.ruleName = ruleName
 	entity.RuleName = ruleName
+
+	g.decimal = g.DecimalMode
+	if d, ok := g.DecimalRules[ruleName]; ok {
+		g.decimal = d
+	}
 }
 
 func (g *GengineParserListener) EnterSalience(ctx *parser.SalienceContext) {
@@ -91,6 +108,61 @@ func (g *GengineParserListener) EnterSalience(ctx *parser.SalienceContext) {
 
 func (g *GengineParserListener) ExitSalience(ctx *parser.SalienceContext) {}
 
+func (g *GengineParserListener) EnterRuleAttribute(ctx *parser.RuleAttributeContext) {}
+
+func (g *GengineParserListener) ExitRuleAttribute(ctx *parser.RuleAttributeContext) {}
+
+func (g *GengineParserListener) EnterNoLoop(ctx *parser.NoLoopContext) {}
+
+func (g *GengineParserListener) ExitNoLoop(ctx *parser.NoLoopContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	entity := g.Stack.Peek().(*base.RuleEntity)
+	entity.NoLoop = true
+}
+
+//the expression of the when condition is accepted by the rule entity
+func (g *GengineParserListener) EnterWhenCondition(ctx *parser.WhenConditionContext) {}
+
+func (g *GengineParserListener) ExitWhenCondition(ctx *parser.WhenConditionContext) {}
+
+func (g *GengineParserListener) EnterAfterRules(ctx *parser.AfterRulesContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	g.Stack.Push(&base.AfterRules{})
+}
+
+func (g *GengineParserListener) ExitAfterRules(ctx *parser.AfterRulesContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	afterRules := g.Stack.Pop().(*base.AfterRules)
+	entity := g.Stack.Peek().(*base.RuleEntity)
+	entity.After = append(entity.After, afterRules.RuleNames...)
+}
+
+func (g *GengineParserListener) EnterGroupName(ctx *parser.GroupNameContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	g.Stack.Push(&base.GroupName{})
+}
+
+func (g *GengineParserListener) ExitGroupName(ctx *parser.GroupNameContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	groupName := g.Stack.Pop().(*base.GroupName)
+	entity := g.Stack.Peek().(*base.RuleEntity)
+	if entity.Group != "" {
+		g.AddError(errors.New(fmt.Sprintf("rule \"%s\" has more than one group", entity.RuleName)))
+		return
+	}
+	entity.Group = groupName.Name
+}
+
 func (g *GengineParserListener) EnterRuleDescription(ctx *parser.RuleDescriptionContext) {}
 
 func (g *GengineParserListener) ExitRuleDescription(ctx *parser.RuleDescriptionContext) {
@@ -142,7 +214,10 @@ func (g *GengineParserListener) EnterAssignment(ctx *parser.AssignmentContext) {
 	if len(g.ParseErrors) > 0 {
 		return
 	}
-	assignment := &base.Assignment{}
+	assignment := &base.Assignment{
+		Decimal:  g.decimal,
+		Overflow: g.Overflow,
+	}
 	g.Stack.Push(assignment)
 }
 
@@ -153,6 +228,7 @@ func (g *GengineParserListener) ExitAssignment(ctx *parser.AssignmentContext) {
 	expr := g.Stack.Pop().(*base.Assignment)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -168,7 +244,10 @@ func (g *GengineParserListener) EnterMathExpression(ctx *parser.MathExpressionCo
 	if len(g.ParseErrors) > 0 {
 		return
 	}
-	me := &base.MathExpression{}
+	me := &base.MathExpression{
+		Decimal:  g.decimal,
+		Overflow: g.Overflow,
+	}
 	g.Stack.Push(me)
 
 }
@@ -180,6 +259,7 @@ func (g *GengineParserListener) ExitMathExpression(ctx *parser.MathExpressionCon
 	expr := g.Stack.Pop().(*base.MathExpression)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -206,6 +286,7 @@ func (g *GengineParserListener) ExitExpression(ctx *parser.ExpressionContext) {
 	expr := g.Stack.Pop().(*base.Expression)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -232,6 +313,7 @@ func (g *GengineParserListener) ExitExpressionAtom(ctx *parser.ExpressionAtomCon
 	expr := g.Stack.Pop().(*base.ExpressionAtom)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -260,6 +342,7 @@ func (g *GengineParserListener) ExitMethodCall(ctx *parser.MethodCallContext) {
 	expr := g.Stack.Pop().(*base.MethodCall)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -276,7 +359,8 @@ func (g *GengineParserListener) EnterFunctionCall(ctx *parser.FunctionCallContex
 		return
 	}
 	funcCall := &base.FunctionCall{
-		FunctionName: ctx.SIMPLENAME().GetText(),
+		// the name is a SIMPLENAME or a soft keyword, such as exit
+		FunctionName: ctx.GetStart().GetText(),
 	}
 	g.Stack.Push(funcCall)
 }
@@ -288,6 +372,7 @@ func (g *GengineParserListener) ExitFunctionCall(ctx *parser.FunctionCallContext
 	expr := g.Stack.Pop().(*base.FunctionCall)
 
 	expr.Code = ctx.GetText()
+	expr.RuleName = g.ruleName
 	expr.LineNum = ctx.GetStart().GetLine()
 	expr.Column = ctx.GetStart().GetColumn()
 	expr.LineStop = ctx.GetStop().GetColumn()
@@ -451,6 +536,15 @@ func (g *GengineParserListener) ExitRealLiteral(ctx *parser.RealLiteralContext)
 		return
 	}
 	cons := g.Stack.Peek().(*base.Constant)
+	if g.decimal {
+		d, err := core.NewDecimalFromString(ctx.GetText())
+		if err != nil {
+			g.AddError(err)
+			return
+		}
+		cons.ConstantValue = d
+		return
+	}
 	flo, err := strconv.ParseFloat(ctx.GetText(), 64)
 	if err != nil {
 		g.AddError(errors.New(fmt.Sprintf("string to float conversion error. String is not real type '%s'", ctx.GetText())))
@@ -489,10 +583,50 @@ func (g *GengineParserListener) ExitStatement(ctx *parser.StatementContext) {
 		return
 	}
 	statement := g.Stack.Pop().(*base.Statement)
+	statement.Code = ctx.GetText()
+	statement.RuleName = g.ruleName
+	statement.LineNum = ctx.GetStart().GetLine()
+	statement.Column = ctx.GetStart().GetColumn()
+	statement.LineStop = ctx.GetStop().GetColumn()
 	statements := g.Stack.Peek().(*base.Statements)
 	statements.StatementList = append(statements.StatementList, statement)
 }
 
+func (g *GengineParserListener) EnterHaltStmt(ctx *parser.HaltStmtContext) {}
+
+func (g *GengineParserListener) ExitHaltStmt(ctx *parser.HaltStmtContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	statement := g.Stack.Peek().(*base.Statement)
+	statement.Halt = true
+}
+
+func (g *GengineParserListener) EnterExitStmt(ctx *parser.ExitStmtContext) {}
+
+func (g *GengineParserListener) ExitExitStmt(ctx *parser.ExitStmtContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	statement := g.Stack.Peek().(*base.Statement)
+	statement.Exit = true
+}
+
+func (g *GengineParserListener) EnterRollbackStmt(ctx *parser.RollbackStmtContext) {}
+
+func (g *GengineParserListener) ExitRollbackStmt(ctx *parser.RollbackStmtContext) {
+	if len(g.ParseErrors) > 0 {
+		return
+	}
+	statement := g.Stack.Peek().(*base.Statement)
+	statement.Rollback = true
+}
+
+// a keyword used as a name, the variable or function which holds it reads the name from its text
+func (g *GengineParserListener) EnterSoftKeyword(ctx *parser.SoftKeywordContext) {}
+
+func (g *GengineParserListener) ExitSoftKeyword(ctx *parser.SoftKeywordContext) {}
+
 func (g *GengineParserListener) EnterStatements(ctx *parser.StatementsContext) {
 	if len(g.ParseErrors) > 0 {
 		return
@@ -564,6 +698,16 @@ func (g *GengineParserListener) ExitInteger(ctx *parser.IntegerContext) {
 	if len(g.ParseErrors) > 0 {
 		return
 	}
+	if cons, ok := g.Stack.Peek().(*base.Constant); ok && g.decimal {
+		d, err := core.NewDecimalFromString(ctx.GetText())
+		if err != nil {
+			g.AddError(err)
+			return
+		}
+		cons.ConstantValue = d
+		return
+	}
+
 	val, err := strconv.ParseInt(ctx.GetText(), 10, 64)
 	if err != nil {
 		g.AddError(err)
@@ -638,6 +782,11 @@ func (g *GengineParserListener) ExitMapVar(ctx *parser.MapVarContext) {
 		return
 	}
 	mapVar := g.Stack.Pop().(*base.MapVar)
+	mapVar.Code = ctx.GetText()
+	mapVar.RuleName = g.ruleName
+	mapVar.LineNum = ctx.GetStart().GetLine()
+	mapVar.Column = ctx.GetStart().GetColumn()
+	mapVar.LineStop = ctx.GetStop().GetColumn()
 	holder := g.Stack.Peek().(base.MapVarHolder)
 	err := holder.AcceptMapVar(mapVar)
 	if err != nil {
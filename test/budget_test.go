@@ -0,0 +1,147 @@
+package test
+
+import (
+	"errors"
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"testing"
+	"time"
+)
+
+const budget_rule = `
+rule "budget" "budget test"
+begin
+Counter.Sum = 1
+Counter.Sum = Add(Counter.Sum, 1)
+Counter.Sum = Add(Counter.Sum, 1)
+Sleep(30)
+Counter.Sum = Add(Counter.Sum, 1)
+end
+`
+
+func execBudget(t *testing.T, limits engine.Limits) (*Counter, error) {
+	counter := &Counter{}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Counter", counter)
+	dataContext.Add("Add", func(a, b int64) int64 { return a + b })
+	dataContext.Add("Sleep", func(ms int64) { time.Sleep(time.Duration(ms) * time.Millisecond) })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(budget_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetLimits(limits)
+	return counter, eng.Execute(ruleBuilder, true)
+}
+
+func Test_budget_statements(t *testing.T) {
+	counter, err := execBudget(t, engine.Limits{MaxStatements: 2})
+	var be *engine.BudgetError
+	if !errors.As(err, &be) || !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Fatalf("want budget error, got %+v", err)
+	}
+	if be.Kind != "statements" || be.RuleName != "budget" || be.LineNum != 6 {
+		t.Errorf("want the third statement of rule budget, got %+v", be)
+	}
+	if counter.Sum != 2 {
+		t.Errorf("only 2 statements should be executed, got sum %d", counter.Sum)
+	}
+}
+
+func Test_budget_calls(t *testing.T) {
+	counter, err := execBudget(t, engine.Limits{MaxCalls: 1})
+	var be *engine.BudgetError
+	if !errors.As(err, &be) {
+		t.Fatalf("want budget error, got %+v", err)
+	}
+	if be.Kind != "calls" || be.RuleName != "budget" || be.LineNum != 6 || be.Column != 14 {
+		t.Errorf("want the second call of rule budget, got %+v", be)
+	}
+	if counter.Sum != 2 {
+		t.Errorf("want sum 2, got %d", counter.Sum)
+	}
+}
+
+func Test_budget_duration(t *testing.T) {
+	counter, err := execBudget(t, engine.Limits{MaxDuration: 10 * time.Millisecond})
+	var be *engine.BudgetError
+	if !errors.As(err, &be) {
+		t.Fatalf("want budget error, got %+v", err)
+	}
+	if be.Kind != "duration" || be.LineNum != 8 {
+		t.Errorf("want the statement after sleep, got %+v", be)
+	}
+	if counter.Sum != 3 {
+		t.Errorf("want sum 3, got %d", counter.Sum)
+	}
+}
+
+func Test_budget_no_limit(t *testing.T) {
+	counter, err := execBudget(t, engine.Limits{})
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if counter.Sum != 4 {
+		t.Errorf("want sum 4, got %d", counter.Sum)
+	}
+}
+
+func Test_budget_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.CONCOURRENT_MODEL, `
+rule "a" begin Counter.Sum = 1 Counter.Sum = 2 end
+rule "b" begin Counter.Left = 1 end
+`, nil)
+	if err != nil {
+		t.Fatalf("new pool err: %+v", err)
+	}
+	pool.SetLimits(engine.Limits{MaxStatements: 1})
+
+	err = pool.ExecuteRules("Counter", &Counter{}, "", nil)
+	if !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Errorf("want budget error, got %+v", err)
+	}
+}
+
+func Test_budget_conc(t *testing.T) {
+	counter := &Counter{}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Counter", counter)
+	dataContext.Add("Add", func(a, b int64) int64 { return a + b })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "conc" "conc block over the budget"
+begin
+conc {
+	a = Add(1, 1)
+	b = Add(2, 2)
+	c = Add(3, 3)
+	d = Add(4, 4)
+}
+Counter.Sum = 1
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetLimits(engine.Limits{MaxCalls: 2})
+	err = eng.Execute(ruleBuilder, true)
+	var be *engine.BudgetError
+	if !errors.As(err, &be) || !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Fatalf("want budget error, got %+v", err)
+	}
+	if be.Kind != "calls" || be.RuleName != "conc" {
+		t.Errorf("want the calls of rule conc, got %+v", be)
+	}
+	if counter.Sum != 0 {
+		t.Errorf("the statements after the conc block should not be executed, got sum %d", counter.Sum)
+	}
+}
@@ -0,0 +1,181 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"math"
+	"testing"
+	"time"
+)
+
+const builtin_rule = `
+rule "builtin" "builtin libraries test"
+begin
+name := strings.Trim(Profile.Raw)
+Profile.Vip = strings.HasPrefix(name, "vip_") && strings.Contains(name, "li")
+Profile.Upper = strings.Upper(strings.Replace(name, "_", "-"))
+Profile.Tags = strings.Join(strings.Split(Profile.TagStr, ","), "|")
+Profile.NameLen = strings.Len("张三")
+
+Profile.Max = math.Max(Profile.Score, 60)
+Profile.Min = math.Min(-3, Profile.Level)
+Profile.Abs = math.Abs(-7)
+Profile.Rounded = math.Round(2.5) + math.Floor(1.9) + math.Ceil(1.1)
+Profile.Pow = math.Pow(2, 10)
+
+Profile.Level = conv.ToInt("42") + conv.ParseInt("8")
+Profile.Ratio = conv.ToFloat(Profile.Level) / conv.ParseFloat("100")
+Profile.Text = conv.ToString(0.1) + conv.ToString(Profile.Level)
+Profile.Enabled = conv.ParseBool("true")
+end
+`
+
+type Profile struct {
+	Raw     string
+	TagStr  string
+	Vip     bool
+	Upper   string
+	Tags    string
+	NameLen int
+	Score   int64
+	Level   int64
+	Max     int64
+	Min     int64
+	Abs     int64
+	Rounded float64
+	Pow     float64
+	Ratio   float64
+	Text    string
+	Enabled bool
+}
+
+func Test_builtin_libraries(t *testing.T) {
+	profile := &Profile{Raw: "  vip_lily ", TagStr: "a,b,c", Score: 50, Level: 5}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Profile", profile)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(builtin_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	err = eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	if !profile.Vip || profile.Upper != "VIP-LILY" || profile.Tags != "a|b|c" || profile.NameLen != 2 {
+		t.Errorf("strings: got %+v", profile)
+	}
+	if profile.Max != 60 || profile.Min != -3 || profile.Abs != 7 || profile.Rounded != 6 || profile.Pow != 1024 {
+		t.Errorf("math: got %+v", profile)
+	}
+	if profile.Level != 50 || profile.Ratio != 0.5 || profile.Text != "0.150" || !profile.Enabled {
+		t.Errorf("conv: got %+v", profile)
+	}
+}
+
+type myStrings struct{}
+
+func (s *myStrings) Upper(str string) string {
+	return "overridden"
+}
+
+func Test_builtin_override(t *testing.T) {
+	profile := &Profile{}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Profile", profile)
+	dataContext.Add("strings", &myStrings{})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "override" "override builtin"
+begin
+Profile.Upper = strings.Upper("abc")
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if profile.Upper != "overridden" {
+		t.Errorf("want overridden, got %s", profile.Upper)
+	}
+}
+
+func Test_builtin_overflow(t *testing.T) {
+	profile := &Profile{Score: math.MinInt64, Level: 5}
+	schedule := &Schedule{Created: time.Unix(0, 0)}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Profile", profile)
+	dataContext.Add("Schedule", schedule)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "abs" salience 10 begin Profile.Abs = math.Abs(Profile.Score) end
+rule "toInt" salience 9 begin Profile.Level = conv.ToInt(decimal.New("1e30")) end
+rule "duration" salience 8 begin Schedule.DayStart = time.Add(Schedule.Created, decimal.New("-1e30")) end
+rule "decimalToInt" salience 7 begin Profile.Min = decimal.ToInt("-1e20") end
+rule "fit" begin Profile.Max = conv.ToInt(decimal.New("12.9")) end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	for _, name := range []string{"abs", "toInt", "duration", "decimalToInt"} {
+		if findRuleError(err, name) == nil {
+			t.Errorf("want the overflow error of rule %s, got %+v", name, err)
+		}
+	}
+	if findRuleError(err, "fit") != nil || profile.Max != 12 || profile.Abs != 0 || profile.Level != 5 || !schedule.DayStart.IsZero() {
+		t.Errorf("want only the values in range assigned, got %+v %+v %+v", err, profile, schedule)
+	}
+}
+
+func Test_builtin_conv_to_int_overflow(t *testing.T) {
+	profile := &Profile{Level: 5}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Profile", profile)
+	dataContext.Add("Big", 1e30)
+	dataContext.Add("NaN", math.NaN())
+	dataContext.Add("Huge", uint64(math.MaxUint64))
+	dataContext.Add("MaxUint", uint64(math.MaxInt64))
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "float" salience 10 begin Profile.Level = conv.ToInt(Big) end
+rule "nan" salience 9 begin Profile.Level = conv.ToInt(NaN) end
+rule "uint" salience 8 begin Profile.Level = conv.ToInt(Huge) end
+rule "string" salience 7 begin Profile.Level = conv.ToInt("1e30") end
+rule "intString" salience 6 begin Profile.Level = conv.ToInt("99999999999999999999") end
+rule "fit" begin
+Profile.Max = conv.ToInt(MaxUint)
+Profile.Min = conv.ToInt(" -12.9 ")
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	for _, name := range []string{"float", "nan", "uint", "string", "intString"} {
+		if findRuleError(err, name) == nil {
+			t.Errorf("want the overflow error of rule %s, got %+v", name, err)
+		}
+	}
+	if findRuleError(err, "fit") != nil || profile.Level != 5 || profile.Max != math.MaxInt64 || profile.Min != -12 {
+		t.Errorf("want only the values in range assigned, got %+v %+v", err, profile)
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"math"
+	"testing"
+)
+
+const compare_rule = `
+rule "compare" "compare big integers"
+begin
+Account.SameId = Account.Id == Account.NextId
+Account.Greater = Account.NextId > Account.Id
+Account.UintGreater = Account.Unsigned > Account.Id
+Account.Negative = Account.Minus < Account.Unsigned
+Account.FloatEqual = Account.Float == Account.Id
+Account.NaN = Account.NaNValue == Account.NaNValue
+end
+`
+
+type Account struct {
+	Id          int64
+	NextId      int64
+	Unsigned    uint64
+	Minus       int64
+	Float       float64
+	NaNValue    float64
+	SameId      bool
+	Greater     bool
+	UintGreater bool
+	Negative    bool
+	FloatEqual  bool
+	NaN         bool
+}
+
+func Test_compare_big_integer(t *testing.T) {
+	// 2^53 + 1 and 2^53 + 2 are the same in float64
+	account := &Account{
+		Id:       1<<53 + 1,
+		NextId:   1<<53 + 2,
+		Unsigned: math.MaxUint64,
+		Minus:    -1,
+		Float:    float64(1 << 53),
+		NaNValue: math.NaN(),
+	}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Account", account)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(compare_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	err = eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	if account.SameId {
+		t.Error("2^53+1 should not be equal to 2^53+2")
+	}
+	if !account.Greater {
+		t.Error("2^53+2 should be greater than 2^53+1")
+	}
+	if !account.UintGreater {
+		t.Error("MaxUint64 should be greater than 2^53+1")
+	}
+	if !account.Negative {
+		t.Error("-1 should be less than MaxUint64")
+	}
+	if account.FloatEqual {
+		t.Error("float64(2^53) should not be equal to 2^53+1")
+	}
+	if account.NaN {
+		t.Error("NaN should not be equal to NaN")
+	}
+}
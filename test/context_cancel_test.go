@@ -0,0 +1,214 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const cancel_rule = `
+rule "first" "cancel the context" salience 100
+begin
+Steps.Add("first")
+Cancel()
+Steps.Add("after cancel")
+end
+
+rule "second" "should not be executed" salience 10
+begin
+Steps.Add("second")
+end
+`
+
+type Steps struct {
+	lock  sync.Mutex
+	count int32
+	names []string
+}
+
+func (s *Steps) Add(name string) {
+	atomic.AddInt32(&s.count, 1)
+	s.lock.Lock()
+	s.names = append(s.names, name)
+	s.lock.Unlock()
+}
+
+func buildCancelRule(t *testing.T, rule string, steps *Steps, cancel func()) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Steps", steps)
+	dataContext.Add("Cancel", cancel)
+	dataContext.Add("Sleep", func(ms int64) { time.Sleep(time.Duration(ms) * time.Millisecond) })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_execute_with_context_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	steps := &Steps{}
+	ruleBuilder := buildCancelRule(t, cancel_rule, steps, cancel)
+
+	err := engine.NewGengine().ExecuteWithContext(ctx, ruleBuilder, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %+v", err)
+	}
+	if !strings.Contains(err.Error(), "\"first\"") {
+		t.Errorf("error should contain the rule name, got %+v", err)
+	}
+	if len(steps.names) != 1 || steps.names[0] != "first" {
+		t.Errorf("only the statements before cancel should be executed, got %+v", steps.names)
+	}
+}
+
+func Test_execute_with_context_deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	steps := &Steps{}
+	ruleBuilder := buildCancelRule(t, `
+rule "slow" "slow downstream call"
+begin
+Sleep(100)
+Steps.Add("after sleep")
+end
+`, steps, func() {})
+
+	err := engine.NewGengine().ExecuteWithContext(ctx, ruleBuilder, true)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %+v", err)
+	}
+	if len(steps.names) != 0 {
+		t.Errorf("the statements after the deadline should not be executed, got %+v", steps.names)
+	}
+}
+
+func Test_execute_concurrent_with_context_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	steps := &Steps{}
+	ruleBuilder := buildCancelRule(t, `
+rule "a" begin Steps.Add("a") end
+rule "b" begin Steps.Add("b") end
+rule "c" begin conc { Steps.Add("c1") Steps.Add("c2") } end
+`, steps, func() {})
+
+	eng := engine.NewGengine()
+	for _, execute := range []func() error{
+		func() error { return eng.ExecuteConcurrentWithContext(ctx, ruleBuilder) },
+		func() error { return eng.ExecuteMixModelWithContext(ctx, ruleBuilder) },
+		func() error { return eng.ExecuteInverseMixModelWithContext(ctx, ruleBuilder) },
+	} {
+		err := execute()
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want context.Canceled, got %+v", err)
+		}
+	}
+	if atomic.LoadInt32(&steps.count) != 0 {
+		t.Errorf("no rule should be executed, got %d steps", steps.count)
+	}
+}
+
+func Test_pool_execute_with_context(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.CONCOURRENT_MODEL, `
+rule "a" begin Steps.Add("a") end
+rule "b" begin Steps.Add("b") end
+`, nil)
+	if err != nil {
+		t.Fatalf("new pool err: %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	steps := &Steps{}
+	err = pool.ExecuteRulesWithContext(ctx, "Steps", steps, "", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want context.Canceled, got %+v", err)
+	}
+	if atomic.LoadInt32(&steps.count) != 0 {
+		t.Errorf("no rule should be executed, got %d steps", steps.count)
+	}
+
+	err = pool.ExecuteRulesWithContext(context.Background(), "Steps", steps, "", nil)
+	if err != nil {
+		t.Errorf("execute err: %+v", err)
+	}
+	if atomic.LoadInt32(&steps.count) != 2 {
+		t.Errorf("want 2 steps, got %d", steps.count)
+	}
+}
+
+func Test_data_context_execution(t *testing.T) {
+	dataContext := gcontext.NewDataContext()
+	exec := dataContext.Execution(gcontext.NewVars(nil))
+	if exec.ContextErr() != nil || exec.CountStatement() != nil || exec.InTransaction() {
+		t.Fatalf("want no execution state without an execution")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	state := &gcontext.Execution{Ctx: ctx}
+	exec = dataContext.Execution(gcontext.NewVars(state))
+	if exec != state || !errors.Is(exec.ContextErr(), context.Canceled) {
+		t.Errorf("want the state of the execution, got %+v", exec)
+	}
+}
+
+type Gate struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (g *Gate) Pass() {
+	g.entered <- struct{}{}
+	<-g.release
+}
+
+func Test_execute_rule_builder_concurrently(t *testing.T) {
+	gate := &Gate{entered: make(chan struct{}), release: make(chan struct{})}
+	steps := &Steps{}
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Gate", gate)
+	dataContext.Add("Steps", steps)
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "gate" salience 10 begin Gate.Pass() end
+rule "after" begin Steps.Add("after") end
+`)
+	if err != nil {
+		t.Fatalf("build rules err: %+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetTracing(true)
+	done := make(chan error)
+	go func() {
+		done <- eng.Execute(ruleBuilder, true)
+	}()
+	<-gate.entered
+
+	// the other execution has its own context and no trace
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = engine.NewGengine().ExecuteWithContext(ctx, ruleBuilder, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want context.Canceled, got %+v", err)
+	}
+
+	close(gate.release)
+	if err := <-done; err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if steps.count != 1 || len(eng.GetTrace().Rules) != 2 {
+		t.Errorf("want both rules executed and traced, got %d steps, %+v", steps.count, eng.GetTrace())
+	}
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Pipeline struct {
+	lock  sync.Mutex
+	Names []string
+}
+
+func (s *Pipeline) Add(name string) {
+	time.Sleep(10 * time.Millisecond)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Names = append(s.Names, name)
+}
+
+func (s *Pipeline) index(name string) int {
+	for i, n := range s.Names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+const dag_rules = `
+rule "report" "after all" salience 100 after "price", "stock"
+begin
+Pipeline.Add("report")
+end
+
+rule "price" "after load" after "load"
+begin
+Pipeline.Add("price")
+end
+
+rule "stock" "after load"
+after "load"
+begin
+Pipeline.Add("stock")
+end
+
+rule "load" "first" salience 1
+begin
+Pipeline.Add("load")
+end
+`
+
+func checkDagPipeline(t *testing.T, pipeline *Pipeline) {
+	if len(pipeline.Names) != 4 {
+		t.Fatalf("want 4 steps, got %+v", pipeline.Names)
+	}
+	if pipeline.index("load") != 0 || pipeline.index("report") != 3 {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
+
+func Test_dag_model(t *testing.T) {
+	dataContext := context.NewDataContext()
+	pipeline := &Pipeline{}
+	dataContext.Add("Pipeline", pipeline)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(dag_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	if after := ruleBuilder.Kc.RuleEntities["report"].After; len(after) != 2 || after[0] != "price" || after[1] != "stock" {
+		t.Errorf("unexpected after %+v", after)
+	}
+
+	eng := engine.NewGengine()
+	err = eng.ExecuteDAG(ruleBuilder)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	checkDagPipeline(t, pipeline)
+
+	pipeline.Names = nil
+	err = eng.ExecuteSelectedRulesDAG(ruleBuilder, []string{"report", "price"})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "price,report" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
+
+func Test_dag_model_cycle(t *testing.T) {
+	ruleBuilder := builder.NewRuleBuilder(context.NewDataContext())
+	err := ruleBuilder.BuildRuleFromString(`
+rule "a" after "c" begin x = 1 end
+rule "b" after "a" begin x = 1 end
+rule "c" after "b" begin x = 1 end
+`)
+	if err == nil || !strings.Contains(err.Error(), "rules dependency cycle: a -> c -> b -> a") {
+		t.Errorf("want cycle error, got %+v", err)
+	}
+
+	err = ruleBuilder.BuildRuleFromString(`rule "a" after "x" begin x = 1 end`)
+	if err == nil || !strings.Contains(err.Error(), "\"x\" which does not exist") {
+		t.Errorf("want unknown rule error, got %+v", err)
+	}
+
+	err = ruleBuilder.BuildRuleFromString(`
+rule "a" begin x = 1 end
+rule "b" after "a" begin x = 1 end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	err = ruleBuilder.BuildRuleWithIncremental(`rule "a" after "b" begin x = 1 end`)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("want cycle error, got %+v", err)
+	}
+	if len(ruleBuilder.Kc.RuleEntities["a"].After) != 0 {
+		t.Errorf("the failed incremental update should not change the rules")
+	}
+}
+
+func Test_dag_model_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.DAG_MODEL, dag_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	pipeline := &Pipeline{}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Pipeline": pipeline})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	checkDagPipeline(t, pipeline)
+
+	err = pool.UpdatePooledRulesIncremental(`rule "load" after "report" begin x = 1 end`)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("want cycle error, got %+v", err)
+	}
+}
+
+func Test_after_names(t *testing.T) {
+	// after is a keyword only in the rule header
+	var got int64
+	dataContext := context.NewDataContext()
+	dataContext.Add("After", func(i int64) { got = i })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "first" begin x = 1 end
+
+rule "names" after "first"
+begin
+after = 3
+After(after + 1)
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().ExecuteDAG(ruleBuilder)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if got != 4 {
+		t.Errorf("want 4, got %d", got)
+	}
+}
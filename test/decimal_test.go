@@ -0,0 +1,229 @@
+package test
+
+import (
+	"fmt"
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"testing"
+)
+
+const decimal_rule = `
+rule "payout" "payout in decimal mode" salience 10
+begin
+Payout.Amount = 0.1 + 0.2
+Payout.Exact = Payout.Amount == 0.3
+Payout.Cents = decimal.Round(Order.Price * 3, 2) * 100
+Payout.Share = decimal.RoundBank(Order.Price / 3, 2)
+Payout.Count += 0.7
+end
+
+rule "float" "float rule" salience 5
+begin
+Payout.FloatSum = 0.1 + 0.2
+end
+`
+
+type Order struct {
+	Price float64
+}
+
+type Payout struct {
+	Amount   float64
+	Exact    bool
+	Cents    int64
+	Share    float64
+	Count    int64
+	FloatSum float64
+}
+
+func Test_decimal_mode(t *testing.T) {
+	order := &Order{Price: 1.115}
+	payout := &Payout{Count: 1}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Order", order)
+	dataContext.Add("Payout", payout)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	ruleBuilder.SetDecimalMode(true)
+	ruleBuilder.SetRuleDecimalMode("float", false)
+	err := ruleBuilder.BuildRuleFromString(decimal_rule)
+	if err != nil {
+		panic(fmt.Sprintf("build rules err:%+v", err))
+	}
+
+	eng := engine.NewGengine()
+	err = eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	if payout.Amount != 0.3 || !payout.Exact {
+		t.Errorf("0.1 + 0.2 should be exactly 0.3, got %v, exact=%v", payout.Amount, payout.Exact)
+	}
+	// 1.115 * 3 = 3.345 -> 3.35
+	if payout.Cents != 335 {
+		t.Errorf("want 335 cents, got %d", payout.Cents)
+	}
+	// 1.115 / 3 = 0.37166... -> 0.37
+	if payout.Share != 0.37 {
+		t.Errorf("want 0.37, got %v", payout.Share)
+	}
+	// 1 + 0.7 = 1.7, truncated when assigned to int64
+	if payout.Count != 1 {
+		t.Errorf("want 1, got %d", payout.Count)
+	}
+	if payout.FloatSum == 0.3 {
+		t.Errorf("rule \"float\" should not be in decimal mode")
+	}
+}
+
+const decimal_overflow_rule = `
+rule "int8" salience 10
+begin
+Small.I8 = 100 + 28
+end
+
+rule "uint16" salience 9
+begin
+Small.U16 = 65535 + 1
+end
+
+rule "negative" salience 8
+begin
+Small.U = 0 - 1.5
+end
+
+rule "param" salience 7
+begin
+SetI8(127.9 + 1)
+end
+
+rule "fit" salience 6
+begin
+Small.I8 = 0 - 128.5
+Small.U16 = 65534.5 + 0.5
+SetI8(0 - 1)
+end
+`
+
+type Small struct {
+	I8  int8
+	U16 uint16
+	U   uint64
+}
+
+func Test_decimal_overflow(t *testing.T) {
+	small := &Small{}
+	var param int8
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Small", small)
+	dataContext.Add("SetI8", func(i int8) { param = i })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	ruleBuilder.SetDecimalMode(true)
+	err := ruleBuilder.BuildRuleFromString(decimal_overflow_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	me, ok := err.(*engine.MultiError)
+	if !ok || len(me.Errors) != 4 {
+		t.Fatalf("want 4 overflow errors, got %+v", err)
+	}
+	for _, name := range []string{"int8", "uint16", "negative", "param"} {
+		if findRuleError(err, name) == nil {
+			t.Errorf("want the overflow error of rule %s, got %+v", name, err)
+		}
+	}
+	if small.I8 != -128 || small.U16 != 65535 || small.U != 0 || param != -1 {
+		t.Errorf("want only the values in range assigned, got %+v %d", small, param)
+	}
+}
+
+func Test_decimal_exponent_limit(t *testing.T) {
+	ruleBuilder := builder.NewRuleBuilder(context.NewDataContext())
+	ruleBuilder.SetDecimalMode(true)
+	err := ruleBuilder.BuildRuleFromString(`rule "huge" begin x = 1e2000000000 end`)
+	if err == nil {
+		t.Fatalf("want the exponent out of range to be rejected")
+	}
+
+	payout := &Payout{}
+	dataContext := context.NewDataContext()
+	dataContext.Add("Payout", payout)
+	ruleBuilder = builder.NewRuleBuilder(dataContext)
+	ruleBuilder.SetDecimalMode(true)
+	err = ruleBuilder.BuildRuleFromString(`
+rule "huge" salience 10 begin Payout.Share = decimal.New("1e-2000000000") end
+rule "fit" begin Payout.Amount = decimal.New("1.5e3") end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if findRuleError(err, "huge") == nil || findRuleError(err, "fit") != nil || payout.Amount != 1500 {
+		t.Errorf("want only the exponent out of range rejected, got %+v %+v", err, payout)
+	}
+}
+
+type Floats struct {
+	F32 float32
+	F64 float64
+	Sum float64
+}
+
+func Test_decimal_float_overflow(t *testing.T) {
+	floats := &Floats{}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Floats", floats)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	ruleBuilder.SetDecimalMode(true)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "f64" begin Floats.F64 = 1e400 end
+rule "f32" begin Floats.F32 = 1e39 end
+rule "to_float" begin Floats.Sum = decimal.ToFloat(-1e400) end
+rule "fit" begin Floats.F32 = 1.5e38 Floats.Sum = decimal.ToFloat(2.5) end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	for _, name := range []string{"f64", "f32", "to_float"} {
+		if findRuleError(err, name) == nil {
+			t.Errorf("want the overflow error of rule %s, got %+v", name, err)
+		}
+	}
+	if findRuleError(err, "fit") != nil || floats.F64 != 0 || floats.F32 != 1.5e38 || floats.Sum != 2.5 {
+		t.Errorf("want only the values in range assigned, got %+v %+v", err, floats)
+	}
+}
+
+func Test_decimal_interface_param(t *testing.T) {
+	var got interface{}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Keep", func(v interface{}) { got = v })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	ruleBuilder.SetDecimalMode(true)
+	err := ruleBuilder.BuildRuleFromString(`rule "keep" begin Keep(0.1 + 0.2) end`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	d, ok := got.(builder.Decimal)
+	if !ok || d.String() != "0.3" {
+		t.Errorf("want the decimal 0.3, got %#v", got)
+	}
+}
@@ -0,0 +1,216 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type Invoice struct {
+	Total     int64
+	Status    string
+	Lines     map[string]int64
+	Cancelled bool
+}
+
+func (i *Invoice) Cancel(reason string) bool {
+	i.Cancelled = true
+	return true
+}
+
+func (i *Invoice) GetTotal() int64 {
+	return i.Total
+}
+
+const dry_run_rules = `
+rule "discount" salience 10
+begin
+Invoice.Total = Invoice.Total - 10
+Invoice.Lines["fee"] = 5
+Scores[1] = 100
+Limit = 50
+end
+
+rule "check" salience 5
+begin
+if Invoice.Total == 90 && Invoice.Lines["fee"] == 5 && Scores[1] == 100 {
+	Invoice.Status = "checked"
+}
+Invoice.Total += 1
+end
+
+rule "cancel" salience 1
+begin
+if Invoice.Cancel("test") {
+	Invoice.Status = "cancelled"
+}
+x = Invoice.GetTotal()
+Invoice.Lines["real"] = x
+end
+`
+
+func buildDryRun(t *testing.T, invoice *Invoice, scores *[]int64, limit *int64) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Invoice", invoice)
+	dataContext.Add("Scores", scores)
+	dataContext.Add("Limit", limit)
+	dataContext.MarkMutating("Invoice.Cancel")
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(dry_run_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_dry_run(t *testing.T) {
+	invoice := &Invoice{Total: 100, Lines: map[string]int64{"item": 100}}
+	scores := []int64{1, 2}
+	limit := int64(20)
+	ruleBuilder := buildDryRun(t, invoice, &scores, &limit)
+
+	eng := engine.NewGengine()
+	eng.SetDryRun(true)
+	err := eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+
+	if invoice.Total != 100 || invoice.Status != "" || invoice.Cancelled || len(invoice.Lines) != 1 || scores[1] != 2 || limit != 20 {
+		t.Errorf("the injected objects should not be modified, got %+v %+v %d", invoice, scores, limit)
+	}
+
+	var changes []string
+	for _, c := range eng.GetChangeSet().Changes {
+		b, _ := json.Marshal(c)
+		changes = append(changes, string(b))
+	}
+	want := []string{
+		`{"path":"Invoice.Total","old":100,"new":90}`,
+		`{"path":"Invoice.Lines[\"fee\"]","old":null,"new":5}`,
+		`{"path":"Scores[1]","old":2,"new":100}`,
+		`{"path":"Limit","old":20,"new":50}`,
+		`{"path":"Invoice.Status","old":"","new":"checked"}`,
+		`{"path":"Invoice.Total","old":90,"new":91}`,
+		`{"path":"Invoice.Cancel","old":null,"new":["test"],"call":true}`,
+		`{"path":"Invoice.Lines[\"real\"]","old":null,"new":100}`,
+	}
+	if strings.Join(changes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("want changes\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(changes, "\n"))
+	}
+
+	eng.SetDryRun(false)
+	err = eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if eng.GetChangeSet() != nil || invoice.Total != 91 || invoice.Status != "cancelled" || !invoice.Cancelled || scores[1] != 100 || limit != 50 {
+		t.Errorf("the writes should be made without dry run, got %+v %+v %d", invoice, scores, limit)
+	}
+}
+
+func Test_dry_run_execution(t *testing.T) {
+	invoice := &Invoice{Total: 100, Lines: map[string]int64{"item": 100}}
+	scores := []int64{1, 2}
+	limit := int64(20)
+	ruleBuilder := buildDryRun(t, invoice, &scores, &limit)
+
+	dryRun := engine.NewGengine()
+	dryRun.SetDryRun(true)
+	dryRun.Execute(ruleBuilder, false)
+	changes := dryRun.GetChangeSet()
+	if changes == nil || invoice.Status != "" {
+		t.Fatalf("want the writes recorded in the change set, got %+v", invoice)
+	}
+
+	// the change set belongs to the execution of the dry run engine, not to the rule builder
+	eng := engine.NewGengine()
+	eng.SetTransactional(true)
+	eng.Execute(ruleBuilder, false)
+	if eng.GetChangeSet() != nil || dryRun.GetChangeSet() != changes || invoice.Status != "cancelled" {
+		t.Errorf("want the writes made by the other engine, got %+v", invoice)
+	}
+}
+
+func Test_dry_run_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, `
+rule "total" begin Invoice.Total = 1 end
+rule "status" begin Invoice.Status = "done" end
+`, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	var paths []string
+	pool.SetDryRunHandler(func(ctx context.Context, changes *engine.ChangeSet) {
+		paths = nil
+		for _, c := range changes.Changes {
+			paths = append(paths, c.Path)
+		}
+	})
+
+	invoice := &Invoice{Total: 100}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Invoice": invoice})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if invoice.Total != 100 || invoice.Status != "" || strings.Join(paths, ",") != "Invoice.Total,Invoice.Status" {
+		t.Errorf("want the writes recorded only, got %+v %+v", invoice, paths)
+	}
+
+	pool.SetDryRunHandler(nil)
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Invoice": invoice})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if invoice.Total != 1 || invoice.Status != "done" {
+		t.Errorf("the writes should be made without dry run, got %+v", invoice)
+	}
+}
+
+func Test_dry_run_handler_shared_engine(t *testing.T) {
+	eng := engine.NewGengine()
+	var lock sync.Mutex
+	var sets []*engine.ChangeSet
+	eng.SetDryRunHandler(func(ctx context.Context, changes *engine.ChangeSet) {
+		lock.Lock()
+		sets = append(sets, changes)
+		lock.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	var invoices []*Invoice
+	for i := 0; i < 3; i++ {
+		invoice := &Invoice{Total: 100}
+		invoices = append(invoices, invoice)
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Invoice", invoice)
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		if err := ruleBuilder.BuildRuleFromString(`rule "total" begin Invoice.Total = Invoice.Total - 10 end`); err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := eng.Execute(ruleBuilder, false); err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(sets) != 3 || eng.GetChangeSet() != nil {
+		t.Fatalf("want the change set of every execution given to the handler only, got %d change sets", len(sets))
+	}
+	for i, changes := range sets {
+		if len(changes.Changes) != 1 || changes.Changes[0].New != int64(90) || invoices[i].Total != 100 {
+			t.Errorf("want the write recorded instead of being made, got %+v %+v", changes.Changes, invoices[i])
+		}
+	}
+}
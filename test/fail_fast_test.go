@@ -0,0 +1,156 @@
+package test
+
+import (
+	"errors"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const fail_fast_rules = `
+rule "first" "the first rule of the mix model" salience 100
+begin
+x = 1
+end
+
+rule "fail" "fails at once" salience 10
+begin
+Fail()
+end
+
+rule "slow1" "many steps" salience 5
+begin
+Pipeline.Add("slow1")
+Pipeline.Add("slow1")
+Pipeline.Add("slow1")
+Pipeline.Add("slow1")
+Pipeline.Add("slow1")
+end
+
+rule "slow2" "many steps" salience 1
+begin
+Pipeline.Add("slow2")
+Pipeline.Add("slow2")
+Pipeline.Add("slow2")
+Pipeline.Add("slow2")
+Pipeline.Add("slow2")
+end
+`
+
+func buildFailFast(t *testing.T, pipeline *Pipeline, fail func()) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Pipeline", pipeline)
+	dataContext.Add("Fail", fail)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(fail_fast_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func checkFailFast(t *testing.T, name string, err error, cancelled string) {
+	var ffe *engine.FailFastError
+	if !errors.As(err, &ffe) {
+		t.Fatalf("%s: want fail fast error, got %+v", name, err)
+	}
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.RuleName != "fail" || re.Kind != engine.KindCall {
+		t.Errorf("%s: want the error of rule fail first, got %+v", name, ffe.Err)
+	}
+	names := append([]string{}, ffe.Cancelled...)
+	sort.Strings(names)
+	if strings.Join(names, ",") != cancelled {
+		t.Errorf("%s: want cancelled rules %s, got %+v", name, cancelled, ffe.Cancelled)
+	}
+}
+
+func Test_fail_fast(t *testing.T) {
+	models := map[string]func(g *engine.Gengine, rb *builder.RuleBuilder) error{
+		"concurrent": func(g *engine.Gengine, rb *builder.RuleBuilder) error { return g.ExecuteConcurrent(rb) },
+		"mix":        func(g *engine.Gengine, rb *builder.RuleBuilder) error { return g.ExecuteMixModel(rb) },
+	}
+
+	for name, execute := range models {
+		pipeline := &Pipeline{}
+		ruleBuilder := buildFailFast(t, pipeline, func() { panic("fail") })
+
+		eng := engine.NewGengine()
+		eng.SetFailFast(true)
+		eng.SetReporting(true)
+		err := execute(eng, ruleBuilder)
+		checkFailFast(t, name, err, "slow1,slow2")
+		if len(pipeline.Names) >= 10 {
+			t.Errorf("%s: the slow rules should be cancelled, got %+v", name, pipeline.Names)
+		}
+		if r := eng.GetReport().Result("slow1"); r.Status != engine.RuleStopped {
+			t.Errorf("%s: the cancelled rule should be stopped, got %+v", name, r)
+		}
+	}
+}
+
+func Test_fail_fast_not_started(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildFailFast(t, pipeline, func() { panic("fail") })
+
+	// one goroutine executes the rules by their order, so the slow rules are not started
+	eng := engine.NewGengine()
+	eng.SetFailFast(true)
+	eng.SetMaxParallelism(1)
+	err := eng.ExecuteConcurrent(ruleBuilder)
+	checkFailFast(t, "not started", err, "slow1,slow2")
+	if len(pipeline.Names) != 0 {
+		t.Errorf("the slow rules should not be started, got %+v", pipeline.Names)
+	}
+}
+
+func Test_fail_fast_disabled(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildFailFast(t, pipeline, func() { panic("fail") })
+
+	err := engine.NewGengine().ExecuteConcurrent(ruleBuilder)
+	var ffe *engine.FailFastError
+	if err == nil || errors.As(err, &ffe) {
+		t.Errorf("want the errors of the rules, got %+v", err)
+	}
+	if len(pipeline.Names) != 10 {
+		t.Errorf("all the rules should be executed, got %+v", pipeline.Names)
+	}
+}
+
+func Test_fail_fast_halt(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildFailFast(t, pipeline, func() {})
+	err := ruleBuilder.BuildRuleWithIncremental(`rule "fail" "halts at once" salience 10 begin halt end`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetFailFast(true)
+	err = eng.ExecuteConcurrent(ruleBuilder)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if len(pipeline.Names) >= 10 {
+		t.Errorf("the slow rules should be cancelled, got %+v", pipeline.Names)
+	}
+}
+
+func Test_fail_fast_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.CONCOURRENT_MODEL, fail_fast_rules, map[string]interface{}{
+		"Fail": func() { panic("fail") },
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	pool.SetFailFast(true)
+
+	pipeline := &Pipeline{}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Pipeline": pipeline})
+	checkFailFast(t, "pool", err, "slow1,slow2")
+}
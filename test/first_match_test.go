@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"testing"
+)
+
+const first_match_rules = `
+rule "vip" "vip price" salience 30
+when Cart.Level == "vip"
+begin
+Cart.Discount = 30
+end
+
+rule "big" "big order price" salience 20
+when Cart.Amount >= 100
+begin
+Cart.Discount = 10
+end
+
+rule "gold" "gold price" salience 10
+when Cart.Level == "gold"
+begin
+Cart.Discount = 5
+end
+
+rule "default" "no discount" salience 0
+begin
+Cart.Discount = 0
+Cart.Append("default")
+end
+`
+
+func buildFirstMatch(t *testing.T, cart *Cart) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Cart", cart)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(first_match_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_first_match(t *testing.T) {
+	cases := []struct {
+		cart     *Cart
+		matched  string
+		discount int64
+	}{
+		{&Cart{Level: "vip", Amount: 200}, "vip", 30},
+		{&Cart{Level: "gold", Amount: 200}, "big", 10},
+		{&Cart{Level: "gold", Amount: 50}, "gold", 5},
+		{&Cart{Amount: 50, Discount: 1}, "default", 0},
+	}
+
+	for _, c := range cases {
+		eng := engine.NewGengine()
+		eng.SetReporting(true)
+		matched, err := eng.ExecuteFirstMatch(buildFirstMatch(t, c.cart))
+		if err != nil {
+			t.Fatalf("execute err:%+v", err)
+		}
+		if matched != c.matched || c.cart.Discount != c.discount {
+			t.Errorf("want %s matched with discount %d, got %s with %+v", c.matched, c.discount, matched, c.cart)
+		}
+		if executed := eng.GetReport().Names(engine.RuleExecuted); len(executed) != 1 || executed[0] != c.matched {
+			t.Errorf("only the matched rule should be executed, got %+v", executed)
+		}
+		if c.matched != "default" && c.cart.Log != "" {
+			t.Errorf("the default rule should not be executed, got %s", c.cart.Log)
+		}
+	}
+}
+
+func Test_first_match_selected(t *testing.T) {
+	cart := &Cart{Level: "vip", Amount: 50}
+	ruleBuilder := buildFirstMatch(t, cart)
+
+	matched, err := engine.NewGengine().ExecuteSelectedRulesFirstMatch(ruleBuilder, []string{"gold", "big"})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if matched != "" || cart.Discount != 0 {
+		t.Errorf("no rule should match, got %s with %+v", matched, cart)
+	}
+
+	matched, err = engine.NewGengine().ExecuteSelectedRulesFirstMatch(ruleBuilder, []string{"default", "vip"})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if matched != "vip" || cart.Discount != 30 {
+		t.Errorf("want vip matched, got %s with %+v", matched, cart)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	matched, err = engine.NewGengine().ExecuteFirstMatchWithContext(ctx, ruleBuilder)
+	if err == nil || matched != "" {
+		t.Errorf("want the cancelled error, got %s %+v", matched, err)
+	}
+}
+
+func Test_first_match_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.FIRST_MATCH_MODEL, first_match_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	var matched []string
+	pool.SetMatchHandler(func(ctx context.Context, ruleName string) {
+		matched = append(matched, ruleName)
+	})
+
+	cart := &Cart{Level: "gold", Amount: 50}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Cart": cart})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Discount != 5 || len(matched) != 1 || matched[0] != "gold" {
+		t.Errorf("want gold matched, got %+v with %+v", matched, cart)
+	}
+
+	cart = &Cart{Level: "vip"}
+	err = pool.ExecuteSelected(map[string]interface{}{"Cart": cart}, []string{"gold", "default"})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Log != "default" || len(matched) != 2 || matched[1] != "default" {
+		t.Errorf("want default matched, got %+v with %+v", matched, cart)
+	}
+
+	cart = &Cart{Amount: 100}
+	name, err := pool.ExecuteFirstMatchWithMultiInput(map[string]interface{}{"Cart": cart})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if name != "big" || cart.Discount != 10 || len(matched) != 3 || matched[2] != "big" {
+		t.Errorf("want big matched, got %s with %+v", name, cart)
+	}
+
+	name, err = pool.ExecuteSelectedRulesFirstMatchWithMultiInput(map[string]interface{}{"Cart": cart}, []string{"vip", "gold"})
+	if err != nil || name != "" || len(matched) != 4 || matched[3] != "" {
+		t.Errorf("no rule should match, got %s %+v", name, err)
+	}
+}
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"errors"
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"testing"
+)
+
+type Cart struct {
+	Amount   int64
+	Discount int64
+	Level    string
+	Log      string
+}
+
+func (c *Cart) Append(s string) {
+	c.Log = c.Log + s
+}
+
+func (c *Cart) GetAmount() int64 {
+	return c.Amount
+}
+
+const forward_chaining_rules = `
+rule "discount" "set discount by level" salience 5
+when Cart.Level == "gold" && Cart.Discount == 0
+begin
+Cart.Discount = 10
+Cart.Append("d")
+end
+
+rule "level" "set level by amount" salience 10
+when Cart.Amount >= 100 && Cart.Level == ""
+begin
+Cart.Level = "gold"
+Cart.Append("l")
+end
+
+rule "big" "raise the amount" salience 1
+when Cart.Amount < 100
+begin
+Cart.Amount = Cart.Amount + 100
+Cart.Append("b")
+end
+`
+
+func execForwardChaining(t *testing.T, rules string, cart *Cart, maxCycles int64) error {
+	dataContext := context.NewDataContext()
+	dataContext.Add("Cart", cart)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetMaxCycles(maxCycles)
+	return eng.ExecuteForwardChaining(ruleBuilder)
+}
+
+func Test_forward_chaining(t *testing.T) {
+	cart := &Cart{Amount: 50}
+	err := execForwardChaining(t, forward_chaining_rules, cart, 0)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	// big raises the amount, then level and discount are activated one by one
+	if cart.Log != "bld" || cart.Level != "gold" || cart.Discount != 10 || cart.Amount != 150 {
+		t.Errorf("unexpected cart %+v", cart)
+	}
+}
+
+func Test_forward_chaining_salience(t *testing.T) {
+	cart := &Cart{Amount: 100, Level: "gold"}
+	err := execForwardChaining(t, forward_chaining_rules, cart, 0)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Log != "d" {
+		t.Errorf("only discount should fire, got %s", cart.Log)
+	}
+}
+
+const no_loop_rules = `
+rule "inc" "increase the amount" no-loop
+when Cart.Amount < 1000
+begin
+Cart.Amount = Cart.Amount + 1
+end
+`
+
+const loop_rules = `
+rule "inc" "increase the amount"
+when Cart.Amount < 1000000
+begin
+Cart.Amount = Cart.Amount + 1
+end
+`
+
+func Test_forward_chaining_no_loop(t *testing.T) {
+	cart := &Cart{}
+	err := execForwardChaining(t, no_loop_rules, cart, 0)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Amount != 1 {
+		t.Errorf("no-loop rule should fire once, got %d", cart.Amount)
+	}
+}
+
+func Test_forward_chaining_max_cycles(t *testing.T) {
+	cart := &Cart{}
+	err := execForwardChaining(t, loop_rules, cart, 10)
+	var be *engine.BudgetError
+	if !errors.As(err, &be) || !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Fatalf("want budget error, got %+v", err)
+	}
+	if be.Kind != "cycles" || be.RuleName != "inc" || be.LineNum != 3 {
+		t.Errorf("want the when condition of rule inc, got %+v", be)
+	}
+	if cart.Amount != 10 {
+		t.Errorf("the rule should fire 10 times, got %d", cart.Amount)
+	}
+}
+
+func Test_when_sort_model(t *testing.T) {
+	dataContext := context.NewDataContext()
+	cart := &Cart{Amount: 50}
+	dataContext.Add("Cart", cart)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(forward_chaining_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	// every rule is executed at most once, level and discount are inactive when they are checked
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Log != "b" {
+		t.Errorf("only big should fire, got %s", cart.Log)
+	}
+}
+
+func Test_when_names(t *testing.T) {
+	// when is a keyword only in the rule header
+	cart := &Cart{}
+	err := execForwardChaining(t, `
+rule "names"
+when Cart.Amount == 0
+begin
+When = 4
+when = When + 1
+Cart.Amount = when
+end
+`, cart, 0)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Amount != 5 {
+		t.Errorf("want amount 5, got %d", cart.Amount)
+	}
+}
+
+func Test_forward_chaining_mutating(t *testing.T) {
+	cart := &Cart{Amount: 50}
+	fired := 0
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Cart", cart)
+	dataContext.Add("Fired", func() { fired++ })
+	dataContext.MarkMutating("Cart.Append")
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "getter" "only read the cart" salience 10
+when Cart.GetAmount() < 100
+begin
+Fired()
+x = Cart.GetAmount()
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	// calling a getter changes nothing, the rule fires once
+	eng := engine.NewGengine()
+	eng.SetMaxCycles(10)
+	err = eng.ExecuteForwardChaining(ruleBuilder)
+	if err != nil || fired != 1 {
+		t.Fatalf("the getter rule should fire once, got %d, err %+v", fired, err)
+	}
+
+	// calling a mutating method changes the cart, the getter rule fires again
+	fired = 0
+	err = ruleBuilder.BuildRuleFromString(`
+rule "getter" "only read the cart" salience 10
+when Cart.GetAmount() < 100
+begin
+Fired()
+x = Cart.GetAmount()
+end
+
+rule "append" "change the cart"
+when Cart.Log == ""
+begin
+Cart.Append("a")
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	err = eng.ExecuteForwardChaining(ruleBuilder)
+	if err != nil || fired != 2 || cart.Log != "a" {
+		t.Errorf("the getter rule should fire again after append, got %d, %+v, err %+v", fired, cart, err)
+	}
+}
+
+func Test_forward_chaining_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.FORWARD_CHAINING_MODEL, forward_chaining_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	cart := &Cart{Amount: 50}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Cart": cart})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if cart.Log != "bld" {
+		t.Errorf("unexpected log %s", cart.Log)
+	}
+
+	pool.SetMaxCycles(1)
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Cart": &Cart{Amount: 50}})
+	if !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Errorf("want budget error, got %+v", err)
+	}
+}
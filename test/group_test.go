@@ -0,0 +1,168 @@
+package test
+
+import (
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const group_rules = `
+rule "base" "base price" salience 10 group "pricing"
+begin
+Pipeline.Add("base")
+end
+
+rule "tax" "tax" salience 5 group "pricing"
+begin
+Pipeline.Add("tax")
+end
+
+rule "vip" "vip discount" salience 20 group "discount"
+when false
+begin
+Pipeline.Add("vip")
+end
+
+rule "coupon" "coupon discount" salience 10 group "discount"
+begin
+Pipeline.Add("coupon")
+end
+
+rule "default" "no discount" group "discount"
+begin
+Pipeline.Add("default")
+end
+
+rule "audit" "no group" salience 100
+begin
+Pipeline.Add("audit")
+end
+`
+
+func buildGroups(t *testing.T, pipeline *Pipeline) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Pipeline", pipeline)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(group_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_group(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildGroups(t, pipeline)
+	if ruleBuilder.Kc.RuleEntities["tax"].Group != "pricing" || ruleBuilder.Kc.RuleEntities["audit"].Group != "" {
+		t.Errorf("unexpected groups")
+	}
+
+	err := engine.NewGengine().ExecuteGroups(ruleBuilder, true, []engine.GroupModel{
+		{Group: "discount", Model: engine.FIRST_MATCH_MODEL},
+		{Group: "pricing", Model: engine.SORT_MODEL},
+		{Group: "", Model: engine.CONCOURRENT_MODEL},
+	})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "coupon,base,tax,audit" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
+
+func Test_group_concurrent(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildGroups(t, pipeline)
+
+	eng := engine.NewGengine()
+	eng.SetReporting(true)
+	err := eng.ExecuteGroups(ruleBuilder, true, []engine.GroupModel{
+		{Group: "discount", Model: engine.CONCOURRENT_MODEL},
+		{Group: "pricing", Model: engine.SORT_MODEL},
+	})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	discount := append([]string{}, pipeline.Names[:2]...)
+	sort.Strings(discount)
+	if strings.Join(discount, ",") != "coupon,default" || strings.Join(pipeline.Names[2:], ",") != "base,tax" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+	if len(eng.GetReport().Rules) != 5 || eng.GetReport().Result("audit") != nil {
+		t.Errorf("only the rules of the groups should be reported, got %+v", eng.GetReport().Rules)
+	}
+}
+
+func Test_group_errors(t *testing.T) {
+	ruleBuilder := buildGroups(t, &Pipeline{})
+	eng := engine.NewGengine()
+
+	err := eng.ExecuteGroups(ruleBuilder, true, []engine.GroupModel{{Group: "shipping", Model: engine.SORT_MODEL}})
+	if err == nil || !strings.Contains(err.Error(), "no rule in group \"shipping\"") {
+		t.Errorf("want unknown group error, got %+v", err)
+	}
+
+	err = eng.ExecuteGroups(ruleBuilder, true, []engine.GroupModel{{Group: "pricing", Model: 100}})
+	if err == nil || !strings.Contains(err.Error(), "group \"pricing\"") {
+		t.Errorf("want unknown model error, got %+v", err)
+	}
+
+	err = ruleBuilder.BuildRuleFromString(`rule "a" group "x" group "y" begin x = 1 end`)
+	if err == nil || !strings.Contains(err.Error(), "more than one group") {
+		t.Errorf("want two groups error, got %+v", err)
+	}
+}
+
+func Test_group_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, group_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	pipeline := &Pipeline{}
+	err = pool.ExecuteGroupsWithMultiInput(map[string]interface{}{"Pipeline": pipeline}, true, []engine.GroupModel{
+		{Group: "pricing", Model: engine.SALIENCE_LAYERED_MODEL},
+		{Group: "discount", Model: engine.FIRST_MATCH_MODEL},
+	})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "base,tax,coupon" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
+
+func Test_group_names(t *testing.T) {
+	// group is a keyword only in the rule header
+	var got []int64
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Record", func(i int64) { got = append(got, i) })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "names" group "counters"
+begin
+group = 1
+Group = group + 1
+x = Group
+Record(x)
+GROUP = x * 10
+Record(GROUP)
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().ExecuteGroups(ruleBuilder, true, []engine.GroupModel{{Group: "counters", Model: engine.SORT_MODEL}})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if len(got) != 2 || got[0] != 2 || got[1] != 20 {
+		t.Errorf("want 2 and 20, got %+v", got)
+	}
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+const halt_exit_rules = `
+rule "first" "exit in if" salience 100
+begin
+Pipeline.Add("first")
+if Pipeline.Stop {
+	Pipeline.Add("halt")
+	halt
+}
+exit
+Pipeline.Add("never")
+end
+
+rule "second" "after halt" salience 50
+begin
+Pipeline.Add("second")
+end
+
+rule "third" "after halt" salience 10
+begin
+Pipeline.Add("third")
+end
+`
+
+type HaltPipeline struct {
+	Pipeline
+	Stop bool
+}
+
+func buildHaltExit(t *testing.T, pipeline *HaltPipeline) *builder.RuleBuilder {
+	dataContext := context.NewDataContext()
+	dataContext.Add("Pipeline", pipeline)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(halt_exit_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_exit(t *testing.T) {
+	pipeline := &HaltPipeline{}
+	ruleBuilder := buildHaltExit(t, pipeline)
+
+	err := engine.NewGengine().Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "first,second,third" {
+		t.Errorf("exit should only stop the rule, got %+v", pipeline.Names)
+	}
+}
+
+func Test_halt(t *testing.T) {
+	models := map[string]func(g *engine.Gengine, rb *builder.RuleBuilder) error{
+		"sort": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.Execute(rb, true)
+		},
+		"stop tag": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteWithStopTagDirect(rb, true, &engine.Stag{})
+		},
+		"mix": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteMixModel(rb)
+		},
+		"selected": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteSelectedRules(rb, []string{"first", "third"})
+		},
+		"forward chaining": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteForwardChaining(rb)
+		},
+		"salience layered": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteSalienceLayered(rb, true)
+		},
+		"dag": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteDAG(rb)
+		},
+	}
+
+	for name, execute := range models {
+		pipeline := &HaltPipeline{Stop: true}
+		ruleBuilder := buildHaltExit(t, pipeline)
+		err := execute(engine.NewGengine(), ruleBuilder)
+		if err != nil {
+			t.Fatalf("%s: execute err:%+v", name, err)
+		}
+		if name != "dag" && strings.Join(pipeline.Names, ",") != "first,halt" {
+			t.Errorf("%s: halt should stop all the rules, got %+v", name, pipeline.Names)
+		}
+		if pipeline.index("never") >= 0 {
+			t.Errorf("%s: halt should stop the rule, got %+v", name, pipeline.Names)
+		}
+	}
+}
+
+func Test_halt_pool(t *testing.T) {
+	for _, em := range []int{engine.SORT_MODEL, engine.MIX_MODEL, engine.INVERSE_MIX_MODEL, engine.SALIENCE_LAYERED_MODEL} {
+		pool, err := engine.NewGenginePool(1, 2, em, halt_exit_rules, nil)
+		if err != nil {
+			t.Fatalf("new pool err:%+v", err)
+		}
+
+		pipeline := &HaltPipeline{Stop: true}
+		err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Pipeline": pipeline})
+		if err != nil {
+			t.Fatalf("model %d: execute err:%+v", em, err)
+		}
+		if em != engine.INVERSE_MIX_MODEL && strings.Join(pipeline.Names, ",") != "first,halt" {
+			t.Errorf("model %d: halt should stop all the rules, got %+v", em, pipeline.Names)
+		}
+		if pipeline.index("never") >= 0 {
+			t.Errorf("model %d: halt should stop the rule, got %+v", em, pipeline.Names)
+		}
+	}
+}
+
+func Test_halt_exit_names(t *testing.T) {
+	var got []int64
+	exits := 0
+	dataContext := context.NewDataContext()
+	dataContext.Add("Exit", func() { exits++ })
+	dataContext.Add("halt", func(i int64) int64 { return i * 10 })
+	dataContext.Add("Record", func(i int64) { got = append(got, i) })
+
+	// only the lowercase statements are keywords, they are names everywhere else
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "names" salience 10
+begin
+exit = 2
+Halt = 5
+Exit()
+Record(halt(exit + Halt))
+if exit < Halt {
+	exit
+}
+Record(1)
+end
+
+rule "next"
+begin
+Record(3)
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if exits != 1 || len(got) != 2 || got[0] != 70 || got[1] != 3 {
+		t.Errorf("want the names to be called and the exit to stop the rule, got %d %+v", exits, got)
+	}
+}
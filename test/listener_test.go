@@ -0,0 +1,160 @@
+package test
+
+import (
+	"context"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const listener_rule = `
+rule "a" "a" salience 30
+begin
+Steps.Add("a")
+end
+
+rule "b" "b" salience 20
+begin
+Steps.Add("b")
+end
+
+rule "c" "c" salience 10
+begin
+Steps.Add("c")
+Unknown()
+end
+`
+
+type recordListener struct {
+	engine.NopListener
+	lock   sync.Mutex
+	events []string
+	veto   string
+}
+
+func (l *recordListener) record(event string) {
+	l.lock.Lock()
+	l.events = append(l.events, event)
+	l.lock.Unlock()
+}
+
+func (l *recordListener) BeforeExecute(ctx context.Context) {
+	l.record("before execute")
+}
+
+func (l *recordListener) BeforeRule(ctx context.Context, ruleName string) bool {
+	l.record("before " + ruleName)
+	return ruleName != l.veto
+}
+
+func (l *recordListener) AfterRule(ctx context.Context, ruleName string, err error, duration time.Duration) {
+	if err != nil {
+		l.record("after " + ruleName + " with error")
+	} else {
+		l.record("after " + ruleName)
+	}
+}
+
+func (l *recordListener) AfterExecute(ctx context.Context, err error) {
+	if err != nil {
+		l.record("after execute with error")
+	} else {
+		l.record("after execute")
+	}
+}
+
+func buildListenerRule(t *testing.T, steps *Steps) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Steps", steps)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(listener_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_listener_sort_model_veto(t *testing.T) {
+	steps := &Steps{}
+	l := &recordListener{veto: "b"}
+
+	eng := engine.NewGengine()
+	eng.AddListener(l)
+	if eng.Execute(buildListenerRule(t, steps), true) == nil {
+		t.Fatal("want error of rule c")
+	}
+
+	want := []string{
+		"before execute",
+		"before a", "after a",
+		"before b",
+		"before c", "after c with error",
+		"after execute with error",
+	}
+	if len(l.events) != len(want) {
+		t.Fatalf("want %v, got %v", want, l.events)
+	}
+	for i := range want {
+		if l.events[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, l.events)
+		}
+	}
+	if len(steps.names) != 2 || steps.names[0] != "a" || steps.names[1] != "c" {
+		t.Errorf("rule b should be vetoed, got %v", steps.names)
+	}
+}
+
+func Test_listener_concurrent_models(t *testing.T) {
+	for _, name := range []string{"concurrent", "mix", "inverse mix"} {
+		steps := &Steps{}
+		l := &recordListener{veto: "a"}
+		eng := engine.NewGengine()
+		eng.AddListener(l)
+		switch name {
+		case "concurrent":
+			_ = eng.ExecuteConcurrent(buildListenerRule(t, steps))
+		case "mix":
+			_ = eng.ExecuteMixModel(buildListenerRule(t, steps))
+		default:
+			_ = eng.ExecuteInverseMixModel(buildListenerRule(t, steps))
+		}
+
+		events := append([]string{}, l.events...)
+		sort.Strings(events)
+		want := []string{"after b", "after c with error", "after execute with error", "before a", "before b", "before c", "before execute"}
+		if len(events) != len(want) {
+			t.Fatalf("%s: want %v, got %v", name, want, events)
+		}
+		for i := range want {
+			if events[i] != want[i] {
+				t.Fatalf("%s: want %v, got %v", name, want, events)
+			}
+		}
+		if l.events[0] != "before execute" || l.events[len(l.events)-1] != "after execute with error" {
+			t.Errorf("%s: execute callbacks should wrap the rule callbacks, got %v", name, l.events)
+		}
+	}
+}
+
+func Test_listener_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.MIX_MODEL, listener_rule, nil)
+	if err != nil {
+		t.Fatalf("new pool err: %+v", err)
+	}
+	l := &recordListener{veto: "c"}
+	pool.AddListener(l)
+
+	steps := &Steps{}
+	err = pool.ExecuteRules("Steps", steps, "", nil)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if len(l.events) != 7 || steps.count != 2 {
+		t.Errorf("want rule c vetoed, got events %v and steps %v", l.events, steps.names)
+	}
+}
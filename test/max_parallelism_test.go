@@ -0,0 +1,235 @@
+package test
+
+import (
+	"fmt"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counts the calls of Work running at the same time
+type Gauge struct {
+	running int32
+	max     int32
+	calls   int32
+}
+
+func (g *Gauge) Work() {
+	n := atomic.AddInt32(&g.running, 1)
+	for {
+		m := atomic.LoadInt32(&g.max)
+		if n <= m || atomic.CompareAndSwapInt32(&g.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(&g.calls, 1)
+	atomic.AddInt32(&g.running, -1)
+}
+
+func parallelRules(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteString(fmt.Sprintf("rule \"r%d\" salience %d\nbegin\nGauge.Work()\nend\n", i, n-i))
+	}
+	return sb.String()
+}
+
+func Test_max_parallelism(t *testing.T) {
+	models := map[string]func(g *engine.Gengine, rb *builder.RuleBuilder) error{
+		"concurrent":  func(g *engine.Gengine, rb *builder.RuleBuilder) error { return g.ExecuteConcurrent(rb) },
+		"mix":         func(g *engine.Gengine, rb *builder.RuleBuilder) error { return g.ExecuteMixModel(rb) },
+		"inverse mix": func(g *engine.Gengine, rb *builder.RuleBuilder) error { return g.ExecuteInverseMixModel(rb) },
+		"selected concurrent": func(g *engine.Gengine, rb *builder.RuleBuilder) error {
+			return g.ExecuteSelectedRulesConcurrent(rb, []string{"r1", "r2", "r3", "r4", "r5", "r6"})
+		},
+	}
+
+	for name, execute := range models {
+		gauge := &Gauge{}
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Gauge", gauge)
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		err := ruleBuilder.BuildRuleFromString(parallelRules(20))
+		if err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+
+		eng := engine.NewGengine()
+		eng.SetMaxParallelism(3)
+		err = execute(eng, ruleBuilder)
+		if err != nil {
+			t.Fatalf("%s: execute err:%+v", name, err)
+		}
+		if gauge.max > 3 {
+			t.Errorf("%s: want at most 3 rules at the same time, got %d", name, gauge.max)
+		}
+		if name != "selected concurrent" && gauge.calls != 20 {
+			t.Errorf("%s: want 20 rules executed, got %d", name, gauge.calls)
+		}
+	}
+}
+
+const conc_parallelism_rule = `
+rule "conc" "many calls in conc"
+begin
+conc {
+	Gauge.Work()
+	Gauge.Work()
+	Gauge.Work()
+	Gauge.Work()
+	Gauge.Work()
+	Gauge.Work()
+}
+end
+`
+
+func Test_max_parallelism_conc(t *testing.T) {
+	gauge := &Gauge{}
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Gauge", gauge)
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(conc_parallelism_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetMaxParallelism(2)
+	err = eng.Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if gauge.max != 2 || gauge.calls != 6 {
+		t.Errorf("want 6 calls with 2 at the same time, got %d calls, %d at the same time", gauge.calls, gauge.max)
+	}
+
+	gauge.max = 0
+	eng.SetMaxParallelism(0)
+	err = eng.Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if gauge.max <= 2 {
+		t.Errorf("want the calls without limit, got %d at the same time", gauge.max)
+	}
+}
+
+func Test_max_parallelism_nested_conc(t *testing.T) {
+	gauge := &Gauge{}
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Gauge", gauge)
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	var sb strings.Builder
+	for i := 0; i < 6; i++ {
+		sb.WriteString(fmt.Sprintf("rule \"c%d\"\nbegin\nconc {\nGauge.Work()\nGauge.Work()\nGauge.Work()\n}\nend\n", i))
+	}
+	err := ruleBuilder.BuildRuleFromString(sb.String())
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetMaxParallelism(3)
+	err = eng.ExecuteConcurrent(ruleBuilder)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if gauge.max > 3 || gauge.calls != 18 {
+		t.Errorf("want 18 calls with at most 3 at the same time, got %d calls, %d at the same time", gauge.calls, gauge.max)
+	}
+}
+
+// the id of the current goroutine, from the first line of its stack: "goroutine 18 [running]:"
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return strings.Fields(string(buf))[1]
+}
+
+func Test_max_parallelism_reuse(t *testing.T) {
+	var lock sync.Mutex
+	ids := make(map[string]bool)
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Gauge", &Gauge{})
+	dataContext.Add("Record", func() {
+		lock.Lock()
+		ids[goroutineID()] = true
+		lock.Unlock()
+		time.Sleep(time.Millisecond)
+	})
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(strings.ReplaceAll(parallelRules(20), "Gauge.Work()", "Record()"))
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetMaxParallelism(4)
+	for i := 0; i < 5; i++ {
+		err = eng.ExecuteConcurrent(ruleBuilder)
+		if err != nil {
+			t.Fatalf("execute err:%+v", err)
+		}
+	}
+	// the 3 goroutines of the engine and the calling one
+	if len(ids) > 4 {
+		t.Errorf("want the goroutines reused by the executions, got %d goroutines", len(ids))
+	}
+}
+
+func Test_max_parallelism_change_while_running(t *testing.T) {
+	eng := engine.NewGengine()
+	eng.SetMaxParallelism(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		gauge := &Gauge{}
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Gauge", gauge)
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		if err := ruleBuilder.BuildRuleFromString(parallelRules(6)); err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if err := eng.ExecuteConcurrent(ruleBuilder); err != nil {
+					t.Errorf("execute err:%+v", err)
+				}
+			}
+			if gauge.calls != 30 || gauge.max > 4 {
+				t.Errorf("want 30 calls with at most 4 at the same time, got %d calls, %d at the same time", gauge.calls, gauge.max)
+			}
+		}()
+	}
+	for n := 3; n <= 4; n++ {
+		time.Sleep(5 * time.Millisecond)
+		eng.SetMaxParallelism(n)
+	}
+	wg.Wait()
+}
+
+func Test_max_parallelism_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.CONCOURRENT_MODEL, parallelRules(10), nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	pool.SetMaxParallelism(2)
+
+	gauge := &Gauge{}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Gauge": gauge})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if gauge.max > 2 || gauge.calls != 10 {
+		t.Errorf("want 10 calls with at most 2 at the same time, got %d calls, %d at the same time", gauge.calls, gauge.max)
+	}
+}
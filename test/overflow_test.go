@@ -0,0 +1,89 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"math"
+	"strings"
+	"testing"
+)
+
+const overflow_rule = `
+rule "overflow" "overflow test"
+begin
+Counter.Sum = Counter.Big + Counter.Big
+Counter.Left = Counter.Small - Counter.Large
+end
+`
+
+type Counter struct {
+	Big   int64
+	Small uint64
+	Large uint64
+	Sum   int64
+	Left  uint64
+}
+
+func execOverflow(mode int) (*Counter, error) {
+	counter := &Counter{Big: math.MaxInt64, Small: 1, Large: 2}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Counter", counter)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.SetOverflowMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	err = ruleBuilder.BuildRuleFromString(overflow_rule)
+	if err != nil {
+		return nil, err
+	}
+
+	eng := engine.NewGengine()
+	return counter, eng.Execute(ruleBuilder, true)
+}
+
+func Test_overflow_wrap(t *testing.T) {
+	counter, err := execOverflow(builder.OVERFLOW_WRAP)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if counter.Sum != -2 {
+		t.Errorf("want -2, got %d", counter.Sum)
+	}
+	if counter.Left != math.MaxUint64 {
+		t.Errorf("want %d, got %d", uint64(math.MaxUint64), counter.Left)
+	}
+}
+
+func Test_overflow_error(t *testing.T) {
+	_, err := execOverflow(builder.OVERFLOW_ERROR)
+	if err == nil {
+		t.Fatal("want overflow error")
+	}
+	if !strings.Contains(err.Error(), "line 4, column 14") || !strings.Contains(err.Error(), "overflows int64") {
+		t.Errorf("error should contain the line and column, got: %+v", err)
+	}
+}
+
+func Test_overflow_saturate(t *testing.T) {
+	counter, err := execOverflow(builder.OVERFLOW_SATURATE)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if counter.Sum != math.MaxInt64 {
+		t.Errorf("want %d, got %d", int64(math.MaxInt64), counter.Sum)
+	}
+	if counter.Left != 0 {
+		t.Errorf("want 0, got %d", counter.Left)
+	}
+}
+
+func Test_overflow_mode_invalid(t *testing.T) {
+	ruleBuilder := builder.NewRuleBuilder(context.NewDataContext())
+	if ruleBuilder.SetOverflowMode(3) == nil {
+		t.Error("want error for unknown overflow mode")
+	}
+}
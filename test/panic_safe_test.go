@@ -0,0 +1,140 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+type PanicHolder struct {
+	Value int64
+}
+
+const panic_rules = `
+rule "nil" "reads a field of a nil pointer" salience 10
+begin
+x = 1
+if Holder.Value == 1 {
+	x = 2
+}
+end
+
+rule "ok" "works" salience 10
+begin
+x = 1
+end
+
+rule "last" "works" salience 10
+begin
+x = 1
+end
+`
+
+func buildPanic(t *testing.T, rules string, data map[string]interface{}) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	for k, v := range data {
+		dataContext.Add(k, v)
+	}
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_panic_statement(t *testing.T) {
+	ruleBuilder := buildPanic(t, panic_rules, map[string]interface{}{"Holder": (*PanicHolder)(nil)})
+
+	for name, execute := range map[string]func(g *engine.Gengine) error{
+		"sort":       func(g *engine.Gengine) error { return g.Execute(ruleBuilder, true) },
+		"concurrent": func(g *engine.Gengine) error { return g.ExecuteConcurrent(ruleBuilder) },
+		"mix":        func(g *engine.Gengine) error { return g.ExecuteMixModel(ruleBuilder) },
+	} {
+		err := execute(engine.NewGengine())
+		var re *engine.RuleError
+		if !errors.As(err, &re) || re.Kind != engine.KindPanic || re.RuleName != "nil" || re.LineNum != 5 || !strings.Contains(re.Code, "Holder.Value") {
+			t.Errorf("%s: want the panic error of the if statement, got %+v", name, err)
+			continue
+		}
+		var pe *engine.PanicError
+		if !errors.As(err, &pe) || pe.Stack == "" {
+			t.Errorf("%s: want the stack of the panic, got %+v", name, err)
+		}
+	}
+}
+
+type panicListener struct {
+	engine.NopListener
+}
+
+func (panicListener) BeforeRule(ctx context.Context, ruleName string) bool {
+	if ruleName == "ok" {
+		panic("listener")
+	}
+	return true
+}
+
+func Test_panic_concurrent(t *testing.T) {
+	ruleBuilder := buildPanic(t, panic_rules, map[string]interface{}{"Holder": &PanicHolder{}})
+
+	eng := engine.NewGengine()
+	eng.AddListener(panicListener{})
+	eng.SetReporting(true)
+	for name, execute := range map[string]func() error{
+		"concurrent":          func() error { return eng.ExecuteConcurrent(ruleBuilder) },
+		"inverse mix":         func() error { return eng.ExecuteInverseMixModel(ruleBuilder) },
+		"selected concurrent": func() error { return eng.ExecuteSelectedRulesConcurrent(ruleBuilder, []string{"nil", "ok", "last"}) },
+		"salience layered":    func() error { return eng.ExecuteSalienceLayered(ruleBuilder, true) },
+	} {
+		err := execute()
+		var re *engine.RuleError
+		var pe *engine.PanicError
+		if !errors.As(err, &re) || re.Kind != engine.KindPanic || re.RuleName != "ok" || !errors.As(err, &pe) || pe.Value != "listener" {
+			t.Errorf("%s: want the panic of rule ok, got %+v", name, err)
+		}
+		if r := eng.GetReport().Result("ok"); r == nil || r.Status != engine.RuleErrored {
+			t.Errorf("%s: the rule should be errored, got %+v", name, r)
+		}
+	}
+}
+
+const conc_panic_rules = `
+rule "conc" "a function panics in conc"
+begin
+conc {
+	x = 1
+	Boom()
+}
+end
+`
+
+func Test_panic_conc(t *testing.T) {
+	ruleBuilder := buildPanic(t, conc_panic_rules, map[string]interface{}{"Boom": func() { panic("boom") }})
+
+	err := engine.NewGengine().Execute(ruleBuilder, true)
+	var re *engine.RuleError
+	var pe *engine.PanicError
+	if !errors.As(err, &re) || re.RuleName != "conc" || re.LineNum != 6 || !errors.As(err, &pe) || pe.Value != "boom" {
+		t.Errorf("want the panic of Boom(), got %+v", err)
+	}
+}
+
+func Test_panic_error_message(t *testing.T) {
+	ruleBuilder := buildPanic(t, conc_panic_rules, map[string]interface{}{"Boom": func() { panic("panic in Boom") }})
+
+	err := engine.NewGengine().Execute(ruleBuilder, true)
+	var pe *engine.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want the panic of Boom(), got %+v", err)
+	}
+	msg := pe.Error()
+	if !strings.HasPrefix(msg, "recovered error: panic in Boom\n") || !strings.HasSuffix(msg, pe.Stack) || !strings.Contains(pe.Stack, "runtime/panic.go") {
+		t.Errorf("want the value and the stack of the panic as they are, got %s", msg)
+	}
+}
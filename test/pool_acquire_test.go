@@ -0,0 +1,140 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"gengine/engine"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const pool_acquire_rule = `
+rule "block" "waits until it is released"
+begin
+Block()
+end
+`
+
+// a pool with 2 engines, Block waits until release is closed
+func blockingPool(t *testing.T, started chan bool, release chan bool) *engine.GenginePool {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, pool_acquire_rule, map[string]interface{}{
+		"Block": func() {
+			started <- true
+			<-release
+		},
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	return pool
+}
+
+func Test_pool_acquire_exhausted(t *testing.T) {
+	started := make(chan bool, 2)
+	release := make(chan bool)
+	pool := blockingPool(t, started, release)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := pool.ExecuteRulesWithMultiInput(map[string]interface{}{}); err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+		}()
+	}
+	<-started
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := pool.ExecuteRulesWithMultiInputWithContext(ctx, map[string]interface{}{})
+	if !errors.Is(err, engine.ErrPoolExhausted) {
+		t.Errorf("want pool exhausted when ctx is done, got %+v", err)
+	}
+
+	pool.SetMaxWait(30 * time.Millisecond)
+	start := time.Now()
+	_, err = pool.ExecuteFirstMatchWithMultiInput(map[string]interface{}{})
+	if !errors.Is(err, engine.ErrPoolExhausted) || time.Since(start) > time.Second {
+		t.Errorf("want pool exhausted after the max wait, got %+v after %v", err, time.Since(start))
+	}
+
+	close(release)
+	wg.Wait()
+
+	// the engines are returned when the executions return
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{})
+	if err != nil {
+		t.Errorf("execute err:%+v", err)
+	}
+}
+
+func Test_pool_acquire_wait(t *testing.T) {
+	started := make(chan bool, 3)
+	release := make(chan bool)
+	pool := blockingPool(t, started, release)
+
+	var done int32
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := pool.ExecuteRulesWithMultiInput(map[string]interface{}{}); err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+			atomic.AddInt32(&done, 1)
+		}()
+	}
+	<-started
+	<-started
+
+	// the third execution waits for an engine
+	select {
+	case <-started:
+		t.Fatalf("only 2 engines can be in use")
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	close(release)
+	wg.Wait()
+	if done != 3 {
+		t.Errorf("want 3 executions, got %d", done)
+	}
+}
+
+func Test_pool_acquire_concurrent(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 3, engine.SORT_MODEL, `rule "count" begin Counter.Add() end`, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Counter": &poolCounter{count: &count}})
+			if err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if count != 50 {
+		t.Errorf("want 50 executions, got %d", count)
+	}
+}
+
+type poolCounter struct {
+	count *int32
+}
+
+func (c *poolCounter) Add() {
+	atomic.AddInt32(c.count, 1)
+}
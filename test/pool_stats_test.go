@@ -0,0 +1,280 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"gengine/engine"
+	"sync"
+	"testing"
+	"time"
+)
+
+const pool_stats_rules = `
+rule "ok" salience 10
+begin
+x = 1
+end
+
+rule "fail" salience 5
+begin
+if Fail {
+	Panic()
+}
+end
+`
+
+type statsSink struct {
+	lock       sync.Mutex
+	waits      int
+	exhausted  int
+	executions map[string]int
+	rules      map[string]int
+	versions   []int64
+}
+
+func (s *statsSink) ObserveWait(wait time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.waits++
+	if errors.Is(err, engine.ErrPoolExhausted) {
+		s.exhausted++
+	}
+}
+
+func (s *statsSink) ObserveExecution(model string, duration time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.executions[model]++
+}
+
+func (s *statsSink) ObserveRule(ruleName string, duration time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rules[ruleName]++
+}
+
+func (s *statsSink) ObserveUpdate(version int64, at time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.versions = append(s.versions, version)
+}
+
+func Test_pool_stats_engines(t *testing.T) {
+	started := make(chan bool, 2)
+	release := make(chan bool)
+	pool := blockingPool(t, started, release)
+
+	stats := pool.Stats()
+	if stats.BaseFree != 1 || stats.BaseInUse != 0 || stats.AdditionFree != 1 || stats.AdditionInUse != 0 {
+		t.Errorf("want all the engines free, got %+v", stats)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := pool.ExecuteRulesWithMultiInput(map[string]interface{}{}); err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+		}()
+	}
+	<-started
+	<-started
+
+	stats = pool.Stats()
+	if stats.BaseFree != 0 || stats.BaseInUse != 1 || stats.AdditionFree != 0 || stats.AdditionInUse != 1 {
+		t.Errorf("want all the engines in use, got %+v", stats)
+	}
+
+	pool.SetMaxWait(20 * time.Millisecond)
+	err := pool.ExecuteRulesWithMultiInput(map[string]interface{}{})
+	if !errors.Is(err, engine.ErrPoolExhausted) {
+		t.Errorf("want pool exhausted, got %+v", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	stats = pool.Stats()
+	if stats.BaseFree != 1 || stats.AdditionFree != 1 || stats.BaseInUse != 0 || stats.AdditionInUse != 0 {
+		t.Errorf("want all the engines returned, got %+v", stats)
+	}
+	if stats.Exhausted != 1 || stats.Executions["sort"] != 2 {
+		t.Errorf("want 1 exhausted and 2 executions, got %+v", stats)
+	}
+
+	var waits int64
+	for _, c := range stats.WaitTime.Counts {
+		waits += c
+	}
+	last := stats.WaitTime.Counts[len(stats.WaitTime.Bounds)]
+	if waits != 3 || len(stats.WaitTime.Counts) != len(stats.WaitTime.Bounds)+1 || stats.WaitTime.Sum < 20*time.Millisecond || last != 0 {
+		t.Errorf("want 3 waits, one of them at least the max wait, got %+v", stats.WaitTime)
+	}
+}
+
+func Test_pool_stats_counters(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, pool_stats_rules, map[string]interface{}{
+		"Panic": func() { panic("fail") },
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	sink := &statsSink{executions: make(map[string]int), rules: make(map[string]int)}
+	pool.SetMetricsSink(sink)
+	pool.SetRuleStats(true)
+
+	stats := pool.Stats()
+	if stats.Version != 1 || stats.UpdatedAt.IsZero() {
+		t.Errorf("want version 1 after the pool is created, got %+v", stats)
+	}
+	created := stats.UpdatedAt
+
+	for _, fail := range []bool{false, false, true} {
+		pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Fail": fail})
+	}
+	_, err = pool.ExecuteFirstMatchWithMultiInput(map[string]interface{}{"Fail": false})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	err = pool.ExecuteSelectedRulesConcurrentWithMultiInput(map[string]interface{}{"Fail": true}, []string{"ok", "fail"})
+	if err == nil {
+		t.Fatalf("want the error of rule fail")
+	}
+
+	stats = pool.Stats()
+	if stats.Executions["sort"] != 3 || stats.Errors["sort"] != 1 || stats.Executions["first_match"] != 1 || stats.Errors["first_match"] != 0 ||
+		stats.Executions["concurrent"] != 1 || stats.Errors["concurrent"] != 1 {
+		t.Errorf("want the executions and errors per model, got %+v %+v", stats.Executions, stats.Errors)
+	}
+	ok, fail := stats.Rules["ok"], stats.Rules["fail"]
+	if ok.Executions != 5 || ok.Errors != 0 || fail.Executions != 4 || fail.Errors != 2 || fail.Max <= 0 || fail.Total < fail.Max {
+		t.Errorf("want the counters per rule, got %+v %+v", ok, fail)
+	}
+
+	err = pool.UpdatePooledRules(`
+rule "ok" salience 10
+begin
+x = 2
+end
+`)
+	if err != nil {
+		t.Fatalf("update err:%+v", err)
+	}
+	stats = pool.Stats()
+	if _, ok := stats.Rules["fail"]; ok || stats.Rules["ok"].Executions != 5 {
+		t.Errorf("want the counters of the removed rule dropped, got %+v", stats.Rules)
+	}
+	pool.ClearPoolRules()
+	stats = pool.Stats()
+	if stats.Version != 3 || !stats.UpdatedAt.After(created) || len(stats.Rules) != 0 || stats.Executions["sort"] != 3 {
+		t.Errorf("want version 3 and only the counters of the executions kept after the updates, got %+v", stats)
+	}
+
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+	if sink.waits != 5 || sink.exhausted != 0 || sink.executions["sort"] != 3 || sink.executions["first_match"] != 1 ||
+		sink.rules["ok"] != 5 || len(sink.versions) != 2 || sink.versions[1] != 3 {
+		t.Errorf("want the sink to receive the metrics, got %+v", sink)
+	}
+}
+
+func Test_pool_stats_context(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, pool_stats_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	pool.SetRuleStats(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool.ExecuteRulesWithMultiInputWithContext(context.Background(), map[string]interface{}{"Fail": false})
+			pool.Stats()
+		}()
+	}
+	wg.Wait()
+
+	stats := pool.Stats()
+	if stats.Executions["sort"] != 20 || stats.Rules["ok"].Executions != 20 || stats.BaseFree != 1 || stats.AdditionFree != 1 {
+		t.Errorf("want 20 executions, got %+v", stats)
+	}
+}
+
+func Test_pool_stats_update_while_running(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, pool_stats_rules, map[string]interface{}{
+		"Panic": func() { panic("fail") },
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	pool.SetRuleStats(true)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Fail": false})
+				pool.IsExist("ok")
+				pool.GetRulesNumber()
+				pool.Stats()
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := pool.UpdatePooledRules(pool_stats_rules); err != nil {
+			t.Fatalf("update err:%+v", err)
+		}
+		if err := pool.UpdatePooledRulesIncremental("rule \"added\"\nbegin\nx = 3\nend\n"); err != nil {
+			t.Fatalf("incremental update err:%+v", err)
+		}
+		pool.ClearPoolRules()
+	}
+	close(done)
+	wg.Wait()
+
+	stats := pool.Stats()
+	if stats.Version != 31 || pool.GetRulesNumber() != 0 || len(stats.Rules) != 0 {
+		t.Errorf("want version 31 and no rules after the updates, got %d rules, %+v", pool.GetRulesNumber(), stats)
+	}
+}
+
+func Test_pool_stats_rules_disabled(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, pool_stats_rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	listener := &recordListener{}
+	pool.AddListener(listener)
+
+	pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Fail": false})
+	stats := pool.Stats()
+	if len(stats.Rules) != 0 || stats.Executions["sort"] != 1 {
+		t.Errorf("want the rules not counted by default, got %+v", stats)
+	}
+
+	pool.SetRuleStats(true)
+	pool.SetRuleStats(true)
+	pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Fail": false})
+	pool.SetRuleStats(false)
+	pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Fail": false})
+	stats = pool.Stats()
+	if stats.Rules["ok"].Executions != 1 || stats.Executions["sort"] != 3 {
+		t.Errorf("want the rules of one execution counted, got %+v", stats.Rules)
+	}
+	if n := len(listener.events); n != 3*6 {
+		t.Errorf("want the listener called in every execution, got %d events %+v", n, listener.events)
+	}
+}
@@ -0,0 +1,196 @@
+package test
+
+import (
+	"context"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const report_rules = `
+rule "ok" "executed" salience 100
+begin
+Pipeline.Add("ok")
+end
+
+rule "inactive" "skipped by when" salience 90
+when false
+begin
+Pipeline.Add("inactive")
+end
+
+rule "fail" "errored" salience 80
+begin
+Fail()
+end
+
+rule "stop" "set the stop tag" salience 70
+begin
+Stop.StopTag = true
+end
+
+rule "last" "stopped by the stop tag" salience 60
+begin
+Pipeline.Add("last")
+end
+`
+
+func buildReport(t *testing.T, pipeline *Pipeline, stag *engine.Stag) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Pipeline", pipeline)
+	dataContext.Add("Stop", stag)
+	dataContext.Add("Fail", func() {
+		panic("fail")
+	})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(report_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func checkStatus(t *testing.T, report *engine.Report, status engine.RuleStatus, names string) {
+	if got := strings.Join(report.Names(status), ","); got != names {
+		t.Errorf("want %s rules %s, got %s", status, names, got)
+	}
+}
+
+func Test_report(t *testing.T) {
+	stag := &engine.Stag{}
+	ruleBuilder := buildReport(t, &Pipeline{}, stag)
+
+	eng := engine.NewGengine()
+	if eng.GetReport() != nil {
+		t.Errorf("reporting should be disabled by default")
+	}
+	eng.SetReporting(true)
+	err := eng.ExecuteWithStopTagDirect(ruleBuilder, true, stag)
+	if err == nil {
+		t.Fatalf("want the error of rule fail")
+	}
+
+	report := eng.GetReport()
+	if len(report.Rules) != 5 || report.Rules[0].RuleName != "ok" || report.Err != err {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	checkStatus(t, report, engine.RuleExecuted, "ok,stop")
+	checkStatus(t, report, engine.RuleSkipped, "inactive")
+	checkStatus(t, report, engine.RuleErrored, "fail")
+	checkStatus(t, report, engine.RuleStopped, "last")
+
+	if r := report.Result("fail"); r.Err == nil || !strings.Contains(r.Err.Error(), "fail") {
+		t.Errorf("want the error of rule fail, got %+v", r)
+	}
+	if r := report.Result("ok"); r.Duration <= 0 || r.Err != nil {
+		t.Errorf("want the duration of rule ok, got %+v", r)
+	}
+}
+
+func Test_report_fail_fast(t *testing.T) {
+	ruleBuilder := buildReport(t, &Pipeline{}, &engine.Stag{})
+
+	eng := engine.NewGengine()
+	eng.SetReporting(true)
+	_ = eng.ExecuteSelectedRulesWithControl(ruleBuilder, false, []string{"last", "fail", "ok"})
+
+	report := eng.GetReport()
+	if len(report.Rules) != 3 {
+		t.Fatalf("only the selected rules should be reported, got %+v", report.Rules)
+	}
+	checkStatus(t, report, engine.RuleExecuted, "ok")
+	checkStatus(t, report, engine.RuleErrored, "fail")
+	checkStatus(t, report, engine.RuleStopped, "last")
+}
+
+func Test_report_concurrent(t *testing.T) {
+	ruleBuilder := buildReport(t, &Pipeline{}, &engine.Stag{})
+
+	eng := engine.NewGengine()
+	eng.SetReporting(true)
+	_ = eng.ExecuteConcurrent(ruleBuilder)
+
+	report := eng.GetReport()
+	if len(report.Rules) != 5 || len(report.Names(engine.RuleErrored)) != 1 {
+		t.Errorf("unexpected report %+v", report.Rules)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = eng.ExecuteWithContext(ctx, ruleBuilder, true)
+	checkStatus(t, eng.GetReport(), engine.RuleStopped, "ok,inactive,fail,stop,last")
+	if r := eng.GetReport().Result("ok"); r.Err == nil {
+		t.Errorf("want the cancelled error of rule ok")
+	}
+}
+
+func Test_report_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, report_rules, map[string]interface{}{
+		"Fail": func() {},
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	var lock sync.Mutex
+	var reports []*engine.Report
+	pool.SetReportHandler(func(ctx context.Context, report *engine.Report) {
+		lock.Lock()
+		reports = append(reports, report)
+		lock.Unlock()
+	})
+
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Pipeline": &Pipeline{}, "Stop": &engine.Stag{}})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("want 1 report, got %d", len(reports))
+	}
+	checkStatus(t, reports[0], engine.RuleExecuted, "ok,fail,stop,last")
+	checkStatus(t, reports[0], engine.RuleSkipped, "inactive")
+}
+
+func Test_report_handler_shared_engine(t *testing.T) {
+	eng := engine.NewGengine()
+	var lock sync.Mutex
+	var reports []*engine.Report
+	eng.SetReportHandler(func(ctx context.Context, report *engine.Report) {
+		lock.Lock()
+		reports = append(reports, report)
+		lock.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		stag := &engine.Stag{}
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Pipeline", &Pipeline{})
+		dataContext.Add("Stop", stag)
+		dataContext.Add("Fail", func() {})
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		if err := ruleBuilder.BuildRuleFromString(report_rules); err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := eng.ExecuteWithStopTagDirect(ruleBuilder, true, stag); err != nil {
+				t.Errorf("execute err:%+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(reports) != 3 || eng.GetReport() != nil {
+		t.Fatalf("want the report of every execution given to the handler only, got %d reports", len(reports))
+	}
+	for _, report := range reports {
+		checkStatus(t, report, engine.RuleExecuted, "ok,fail,stop")
+		checkStatus(t, report, engine.RuleStopped, "last")
+	}
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+const rule_error_rules = `
+rule "call" "the function panics" salience 10
+begin
+x = 1
+Boom()
+end
+
+rule "type" "assign a string to an int" salience 5
+begin
+Counter.Sum = "abc"
+end
+`
+
+func buildRuleError(t *testing.T) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Counter", &Counter{})
+	dataContext.Add("Boom", func() {
+		panic("boom")
+	})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rule_error_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func findRuleError(err error, ruleName string) *engine.RuleError {
+	me, ok := err.(*engine.MultiError)
+	if !ok {
+		return nil
+	}
+	for _, e := range me.Errors {
+		var re *engine.RuleError
+		if errors.As(e, &re) && re.RuleName == ruleName {
+			return re
+		}
+	}
+	return nil
+}
+
+func Test_rule_error(t *testing.T) {
+	ruleBuilder := buildRuleError(t)
+
+	err := engine.NewGengine().Execute(ruleBuilder, true)
+	me, ok := err.(*engine.MultiError)
+	if !ok || len(me.Errors) != 2 {
+		t.Fatalf("want 2 errors in a *MultiError, got %+v", err)
+	}
+
+	call := findRuleError(err, "call")
+	if call == nil || call.Kind != engine.KindCall || call.LineNum != 5 || call.Column != 0 || !strings.Contains(call.Code, "Boom()") {
+		t.Errorf("unexpected error of rule call %+v", call)
+	} else if !strings.Contains(call.Cause.Error(), "boom") {
+		t.Errorf("want the panic as cause, got %+v", call.Cause)
+	}
+
+	typ := findRuleError(err, "type")
+	if typ == nil || typ.Kind != engine.KindType || typ.LineNum != 10 || !strings.Contains(typ.Code, "Counter.Sum") {
+		t.Errorf("unexpected error of rule type %+v", typ)
+	}
+
+	var re *engine.RuleError
+	if !errors.As(err, &re) {
+		t.Errorf("errors.As should find a *RuleError in %+v", err)
+	}
+}
+
+func Test_rule_error_concurrent(t *testing.T) {
+	ruleBuilder := buildRuleError(t)
+
+	err := engine.NewGengine().ExecuteConcurrent(ruleBuilder)
+	if findRuleError(err, "call") == nil || findRuleError(err, "type") == nil {
+		t.Errorf("want the errors of both rules, got %+v", err)
+	}
+}
+
+func Test_rule_error_cancelled(t *testing.T) {
+	ruleBuilder := buildRuleError(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := engine.NewGengine().ExecuteWithContext(ctx, ruleBuilder, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %+v", err)
+	}
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.Kind != engine.KindCancelled {
+		t.Errorf("want a cancelled rule error, got %+v", err)
+	}
+}
+
+func Test_rule_error_budget(t *testing.T) {
+	err := execForwardChaining(t, loop_rules, &Cart{}, 10)
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.Kind != engine.KindBudget || re.RuleName != "inc" {
+		t.Fatalf("want a budget rule error, got %+v", err)
+	}
+	var be *engine.BudgetError
+	if !errors.As(err, &be) || !errors.Is(err, engine.ErrBudgetExceeded) {
+		t.Errorf("the cause should be the budget error, got %+v", err)
+	}
+}
+
+func Test_rule_error_parse(t *testing.T) {
+	ruleBuilder := builder.NewRuleBuilder(gcontext.NewDataContext())
+	err := ruleBuilder.BuildRuleFromString(`
+rule "bad" begin
+x =
+end
+`)
+	if _, ok := err.(*engine.MultiError); !ok {
+		t.Fatalf("want a *MultiError, got %+v", err)
+	}
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.Kind != engine.KindParse || re.LineNum == 0 {
+		t.Errorf("want a parse rule error with the position, got %+v", err)
+	}
+}
+
+func Test_rule_error_select(t *testing.T) {
+	ruleBuilder := buildRuleError(t)
+	listener := &recordListener{}
+	eng := engine.NewGengine()
+	eng.SetReporting(true)
+	eng.AddListener(listener)
+
+	_, err := eng.ExecuteSelectedRulesFirstMatch(ruleBuilder, []string{"missing"})
+	if _, ok := err.(*engine.MultiError); !ok {
+		t.Fatalf("want a *MultiError, got %+v", err)
+	}
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.Kind != engine.KindSelect || !strings.Contains(re.Error(), "no rule has been selected") {
+		t.Errorf("want a select rule error, got %+v", err)
+	}
+	report := eng.GetReport()
+	if report == nil || report.Err != err || len(report.Rules) != 0 {
+		t.Errorf("want an empty report with the error, got %+v", report)
+	}
+	if strings.Join(listener.events, ",") != "before execute,after execute with error" {
+		t.Errorf("unexpected events %+v", listener.events)
+	}
+
+	err = eng.Execute(builder.NewRuleBuilder(gcontext.NewDataContext()), true)
+	if !errors.As(err, &re) || re.Kind != engine.KindSelect || err.Error() == "" {
+		t.Errorf("want a select rule error without rules, got %+v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"fmt"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+// the rules "z0", "y1" ... "b24" in the sources, the names are not in the source order
+func equalSalienceRules(n int) (string, []string) {
+	var sb strings.Builder
+	var names []string
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("%c%d", 'z'-i, i)
+		names = append(names, name)
+		sb.WriteString(fmt.Sprintf("rule \"%s\" salience 10\nbegin\nSteps.Add(\"%s\")\nend\n", name, name))
+	}
+	sb.WriteString("rule \"top\" salience 20\nbegin\nSteps.Add(\"top\")\nend\n")
+	sb.WriteString("rule \"bottom\"\nbegin\nSteps.Add(\"bottom\")\nend\n")
+	return sb.String(), append(append([]string{"top"}, names...), "bottom")
+}
+
+func sortRuleNames(rb *builder.RuleBuilder) string {
+	var names []string
+	for i, r := range rb.Kc.SortRules {
+		if rb.Kc.SortRulesIndexMap[r.RuleName] != i {
+			return fmt.Sprintf("wrong index of %s", r.RuleName)
+		}
+		names = append(names, r.RuleName)
+	}
+	return strings.Join(names, ",")
+}
+
+func Test_rule_order(t *testing.T) {
+	rules, names := equalSalienceRules(25)
+	want := strings.Join(names, ",")
+
+	for i := 0; i < 50; i++ {
+		steps := &Steps{}
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Steps", steps)
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		err := ruleBuilder.BuildRuleFromString(rules)
+		if err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+		if got := sortRuleNames(ruleBuilder); got != want {
+			t.Fatalf("build %d: want %s, got %s", i, want, got)
+		}
+
+		err = engine.NewGengine().Execute(ruleBuilder, true)
+		if err != nil {
+			t.Fatalf("execute err:%+v", err)
+		}
+		if got := strings.Join(steps.names, ","); got != want {
+			t.Fatalf("execute %d: want %s, got %s", i, want, got)
+		}
+	}
+}
+
+func Test_rule_order_incremental(t *testing.T) {
+	rules, _ := equalSalienceRules(5)
+	for i := 0; i < 50; i++ {
+		ruleBuilder := builder.NewRuleBuilder(gcontext.NewDataContext())
+		err := ruleBuilder.BuildRuleFromString(rules)
+		if err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+
+		// replaced rules keep their places, added rules are after the existed ones by their order
+		err = ruleBuilder.BuildRuleWithIncremental(`
+rule "n1" salience 10 begin x = 1 end
+rule "x2" salience 10 begin x = 2 end
+rule "a1" salience 10 begin x = 1 end
+rule "m1" salience 20 begin x = 1 end
+`)
+		if err != nil {
+			t.Fatalf("incremental build err:%+v", err)
+		}
+		want := "top,m1,z0,y1,x2,w3,v4,n1,a1,bottom"
+		if got := sortRuleNames(ruleBuilder); got != want {
+			t.Fatalf("incremental build %d: want %s, got %s", i, want, got)
+		}
+
+		// a rule with a changed salience moves to its new layer and keeps its order in it
+		err = ruleBuilder.BuildRuleWithIncremental(`
+rule "top" salience 10 begin x = 1 end
+rule "y1" salience 0 begin x = 1 end
+`)
+		if err != nil {
+			t.Fatalf("incremental build err:%+v", err)
+		}
+		want = "m1,z0,x2,w3,v4,top,n1,a1,y1,bottom"
+		if got := sortRuleNames(ruleBuilder); got != want {
+			t.Fatalf("incremental build %d: want %s, got %s", i, want, got)
+		}
+	}
+}
+
+func Test_rule_order_pool(t *testing.T) {
+	rules, names := equalSalienceRules(10)
+	want := strings.Join(names, ",")
+
+	pool, err := engine.NewGenginePool(5, 10, engine.SORT_MODEL, rules, nil)
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	for i := 0; i < 20; i++ {
+		steps := &Steps{}
+		err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Steps": steps})
+		if err != nil {
+			t.Fatalf("execute err:%+v", err)
+		}
+		if got := strings.Join(steps.names, ","); got != want {
+			t.Fatalf("execute %d: want %s, got %s", i, want, got)
+		}
+	}
+
+	err = pool.UpdatePooledRulesIncremental(`
+rule "new" salience 10 begin Steps.Add("new") end
+rule "v4" salience 10 begin Steps.Add("v4") end
+`)
+	if err != nil {
+		t.Fatalf("update err:%+v", err)
+	}
+	want = strings.Replace(want, ",bottom", ",new,bottom", 1)
+	for i := 0; i < 20; i++ {
+		steps := &Steps{}
+		err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Steps": steps})
+		if err != nil {
+			t.Fatalf("execute err:%+v", err)
+		}
+		if got := strings.Join(steps.names, ","); got != want {
+			t.Fatalf("execute after update %d: want %s, got %s", i, want, got)
+		}
+	}
+}
+
+func Test_rule_order_selected(t *testing.T) {
+	rules, names := equalSalienceRules(5)
+	want := strings.Join(names, ",")
+
+	steps := &Steps{}
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Steps", steps)
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	// the selected rules are in the order of the sort model whatever the order of the names is
+	reversed := make([]string, len(names))
+	for i, name := range names {
+		reversed[len(names)-1-i] = name
+	}
+	eng := engine.NewGengine()
+	for name, execute := range map[string]func() error{
+		"selected":          func() error { return eng.ExecuteSelectedRules(ruleBuilder, reversed) },
+		"selected control":  func() error { return eng.ExecuteSelectedRulesWithControl(ruleBuilder, true, reversed) },
+		"selected chaining": func() error { return eng.ExecuteSelectedRulesForwardChaining(ruleBuilder, reversed) },
+	} {
+		steps.names = nil
+		err = execute()
+		if err != nil {
+			t.Fatalf("%s: execute err:%+v", name, err)
+		}
+		if got := strings.Join(steps.names, ","); got != want {
+			t.Errorf("%s: want %s, got %s", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+const salience_layered_rules = `
+rule "b1" "second layer" salience 5
+begin
+Pipeline.Add("b")
+end
+
+rule "a1" "first layer" salience 10
+begin
+Pipeline.Add("a")
+end
+
+rule "b2" "second layer" salience 5
+begin
+Pipeline.Add("b")
+Fail(Pipeline)
+end
+
+rule "a2" "first layer" salience 10
+begin
+Pipeline.Add("a")
+Stop.StopTag = true
+end
+
+rule "c" "third layer" salience 1
+begin
+Pipeline.Add("c")
+end
+`
+
+func buildSalienceLayered(t *testing.T, pipeline *Pipeline, fail bool, stag *engine.Stag) *builder.RuleBuilder {
+	dataContext := context.NewDataContext()
+	dataContext.Add("Pipeline", pipeline)
+	dataContext.Add("Stop", stag)
+	dataContext.Add("Fail", func(p *Pipeline) {
+		if fail {
+			panic("fail")
+		}
+	})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(salience_layered_rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func Test_salience_layered(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildSalienceLayered(t, pipeline, false, &engine.Stag{})
+
+	err := engine.NewGengine().ExecuteSalienceLayered(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "a,a,b,b,c" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
+
+func Test_salience_layered_fail_fast(t *testing.T) {
+	pipeline := &Pipeline{}
+	ruleBuilder := buildSalienceLayered(t, pipeline, true, &engine.Stag{})
+
+	err := engine.NewGengine().ExecuteSalienceLayered(ruleBuilder, false)
+	if err == nil || !strings.Contains(err.Error(), "b2") {
+		t.Errorf("want the error of b2, got %+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "a,a,b,b" {
+		t.Errorf("the third layer should not be executed, got %+v", pipeline.Names)
+	}
+
+	pipeline.Names = nil
+	err = engine.NewGengine().ExecuteSalienceLayered(ruleBuilder, true)
+	if err == nil {
+		t.Errorf("want the error of b2")
+	}
+	if strings.Join(pipeline.Names, ",") != "a,a,b,b,c" {
+		t.Errorf("the third layer should be executed, got %+v", pipeline.Names)
+	}
+}
+
+func Test_salience_layered_stop_tag(t *testing.T) {
+	pipeline := &Pipeline{}
+	stag := &engine.Stag{}
+	ruleBuilder := buildSalienceLayered(t, pipeline, false, stag)
+
+	err := engine.NewGengine().ExecuteSalienceLayeredWithStopTagDirect(ruleBuilder, true, stag)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "a,a" {
+		t.Errorf("only the first layer should be executed, got %+v", pipeline.Names)
+	}
+}
+
+func Test_salience_layered_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SALIENCE_LAYERED_MODEL, salience_layered_rules, map[string]interface{}{
+		"Fail": func(p *Pipeline) {},
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+
+	pipeline := &Pipeline{}
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Pipeline": pipeline, "Stop": &engine.Stag{}})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if strings.Join(pipeline.Names, ",") != "a,a,b,b,c" {
+		t.Errorf("unexpected order %+v", pipeline.Names)
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"gengine/builder"
+	"gengine/context"
+	"gengine/engine"
+	"testing"
+	"time"
+)
+
+const time_rule = `
+rule "time" "time test"
+begin
+now := time.Now()
+Schedule.Today = time.Format(now, "2006-01-02")
+Schedule.Days = time.DiffDays(now, Schedule.Created)
+Schedule.Weekday = time.Weekday(now)
+Schedule.DayStart = time.StartOfDay(now, "Asia/Shanghai")
+Schedule.Expired = now > time.Add(Schedule.Created, "720h")
+Schedule.LongWait = Schedule.Wait >= time.ParseDuration("1h")
+end
+`
+
+type Schedule struct {
+	Created  time.Time
+	Wait     time.Duration
+	Today    string
+	Days     int64
+	Weekday  int
+	DayStart time.Time
+	Expired  bool
+	LongWait bool
+}
+
+// Thursday in UTC, but already Friday in Asia/Shanghai
+var fixedNow = time.Date(2020, 12, 3, 20, 0, 0, 0, time.UTC)
+
+func Test_time_fixed_clock(t *testing.T) {
+	schedule := &Schedule{
+		Created: time.Date(2020, 11, 1, 12, 0, 0, 0, time.UTC),
+		Wait:    90 * time.Minute,
+	}
+
+	dataContext := context.NewDataContext()
+	dataContext.Add("Schedule", schedule)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(time_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetClock(func() time.Time { return fixedNow })
+	err = eng.Execute(ruleBuilder, false)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	if schedule.Today != "2020-12-03" {
+		t.Errorf("want 2020-12-03, got %s", schedule.Today)
+	}
+	if schedule.Days != 32 {
+		t.Errorf("want 32 days, got %d", schedule.Days)
+	}
+	if schedule.Weekday != int(time.Thursday) {
+		t.Errorf("want Thursday, got %d", schedule.Weekday)
+	}
+	if !schedule.DayStart.Equal(time.Date(2020, 12, 3, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("want the start of 2020-12-04 in Asia/Shanghai, got %s", schedule.DayStart)
+	}
+	if !schedule.Expired {
+		t.Error("now should be after created + 30 days")
+	}
+	if !schedule.LongWait {
+		t.Error("90m should be longer than 1h")
+	}
+}
+
+func Test_time_pool_clock(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, time_rule, nil)
+	if err != nil {
+		t.Fatalf("new pool err: %+v", err)
+	}
+	pool.SetClock(func() time.Time { return fixedNow })
+
+	schedule := &Schedule{Created: fixedNow}
+	err = pool.ExecuteRules("Schedule", schedule, "", nil)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if schedule.Today != "2020-12-03" || schedule.Days != 0 || schedule.Expired {
+		t.Errorf("pool should use the fixed clock, got %+v", schedule)
+	}
+}
@@ -0,0 +1,260 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const trace_rule = `
+rule "reject" "reject low score" salience 10
+begin
+if Applicant.Score < 60 && Applicant.Age >= 18 {
+	Applicant.Result = "rejected"
+} else if Applicant.Score < 80 {
+	Applicant.Result = "review"
+}
+end
+
+rule "vip" "vip bonus" salience 5
+begin
+if Applicant.Vip {
+	Applicant.Score += 10
+}
+end
+`
+
+type Applicant struct {
+	Score  int64
+	Age    int64
+	Vip    bool
+	Result string
+}
+
+func Test_trace(t *testing.T) {
+	applicant := &Applicant{Score: 50, Age: 20}
+
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Applicant", applicant)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(trace_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetTracing(true)
+	err = eng.Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	trace := eng.GetTrace()
+	if trace == nil || len(trace.Rules) != 2 {
+		t.Fatalf("want 2 traced rules, got %+v", trace)
+	}
+
+	reject := trace.Rules[0]
+	if reject.RuleName != "reject" || len(reject.Conditions) != 1 {
+		t.Fatalf("want the if condition of rule reject, got %+v", reject)
+	}
+	cond := reject.Conditions[0]
+	if cond.Kind != "if" || !cond.Result || cond.LineNum != 4 || len(cond.Operands) != 2 {
+		t.Errorf("unexpected condition: %+v", cond)
+	}
+	if op := cond.Operands[0]; op.Left != int64(50) || op.Operator != "<" || op.Right != int64(60) || op.Result != true {
+		t.Errorf("unexpected operand: %+v", op)
+	}
+	if len(reject.Assignments) != 1 {
+		t.Fatalf("want 1 assignment, got %+v", reject.Assignments)
+	}
+	if as := reject.Assignments[0]; as.Target != "Applicant.Result" || as.Old != "" || as.New != "rejected" {
+		t.Errorf("unexpected assignment: %+v", as)
+	}
+
+	vip := trace.Rules[1]
+	if len(vip.Conditions) != 1 || vip.Conditions[0].Result || len(vip.Assignments) != 0 {
+		t.Errorf("the if of rule vip should be false, got %+v", vip)
+	}
+
+	bs, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("marshal trace err: %+v", err)
+	}
+	if !strings.Contains(string(bs), `"rule":"reject"`) || !strings.Contains(string(bs), `"new":"rejected"`) {
+		t.Errorf("unexpected json: %s", bs)
+	}
+}
+
+func Test_trace_disabled(t *testing.T) {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Applicant", &Applicant{Score: 50, Age: 20})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(trace_rule)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetTracing(true)
+	eng.Execute(ruleBuilder, true)
+	if eng.GetTrace().Rule("reject") == nil {
+		t.Fatalf("want the trace of rule reject")
+	}
+
+	eng.SetTracing(false)
+	err = eng.Execute(ruleBuilder, true)
+	if err != nil || eng.GetTrace() != nil {
+		t.Errorf("want no trace once tracing is disabled, got %+v", err)
+	}
+}
+
+func Test_trace_else_if_and_error(t *testing.T) {
+	applicant := &Applicant{Score: 70, Age: 20}
+
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Applicant", applicant)
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(trace_rule + `
+rule "broken" "call an unknown function" salience 1
+begin
+Unknown()
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetTracing(true)
+	if eng.Execute(ruleBuilder, true) == nil {
+		t.Fatal("want error of rule broken")
+	}
+
+	trace := eng.GetTrace()
+	reject := trace.Rules[0]
+	if len(reject.Conditions) != 2 || reject.Conditions[0].Result || reject.Conditions[1].Kind != "else if" || !reject.Conditions[1].Result {
+		t.Errorf("want the else if branch, got %+v", reject.Conditions)
+	}
+	if broken := trace.Rules[2]; broken.RuleName != "broken" || broken.Error == "" {
+		t.Errorf("want the error of rule broken, got %+v", broken)
+	}
+}
+
+func Test_trace_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.SORT_MODEL, trace_rule, nil)
+	if err != nil {
+		t.Fatalf("new pool err: %+v", err)
+	}
+
+	var traced *engine.Trace
+	pool.SetTraceHandler(func(ctx context.Context, trace *engine.Trace) {
+		traced = trace
+	})
+
+	err = pool.ExecuteRules("Applicant", &Applicant{Score: 90, Vip: true}, "", nil)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+	if traced == nil || len(traced.Rules) != 2 || len(traced.Rules[1].Assignments) != 1 {
+		t.Errorf("want the trace of the execution, got %+v", traced)
+	}
+	if as := traced.Rules[1].Assignments[0]; as.Old != int64(90) || as.New != int64(100) {
+		t.Errorf("unexpected assignment: %+v", as)
+	}
+}
+
+type traceKey struct{}
+
+func Test_trace_handler_shared_engine(t *testing.T) {
+	eng := engine.NewGengine()
+	var lock sync.Mutex
+	traces := make(map[int64]*engine.Trace)
+	eng.SetTraceHandler(func(ctx context.Context, trace *engine.Trace) {
+		lock.Lock()
+		traces[ctx.Value(traceKey{}).(int64)] = trace
+		lock.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for _, score := range []int64{50, 70, 90} {
+		dataContext := gcontext.NewDataContext()
+		dataContext.Add("Applicant", &Applicant{Score: score, Age: 20})
+		ruleBuilder := builder.NewRuleBuilder(dataContext)
+		if err := ruleBuilder.BuildRuleFromString(trace_rule); err != nil {
+			t.Fatalf("build rules err:%+v", err)
+		}
+		ctx := context.WithValue(context.Background(), traceKey{}, score)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := eng.ExecuteWithContext(ctx, ruleBuilder, true); err != nil {
+				t.Errorf("execute err: %+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(traces) != 3 || eng.GetTrace() != nil {
+		t.Fatalf("want the trace of every execution given to the handler only, got %+v", traces)
+	}
+	for score, branches := range map[int64]int{50: 1, 70: 2, 90: 2} {
+		if conds := traces[score].Rules[0].Conditions; len(conds) != branches {
+			t.Errorf("want %d conditions for score %d, got %+v", branches, score, conds)
+		}
+	}
+}
+
+func Test_trace_forward_chaining(t *testing.T) {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Cart", &Cart{})
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "inc" "increase the amount twice"
+when Cart.Amount < 2
+begin
+Cart.Amount = Cart.Amount + 1
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	eng := engine.NewGengine()
+	eng.SetTracing(true)
+	err = eng.ExecuteForwardChaining(ruleBuilder)
+	if err != nil {
+		t.Fatalf("execute err: %+v", err)
+	}
+
+	// every firing has the when condition evaluated before it and its own assignment, the last evaluation is skipped
+	rules := eng.GetTrace().Rules
+	if len(rules) != 3 {
+		t.Fatalf("want 2 firings and 1 skipped evaluation, got %+v", rules)
+	}
+	for i, rt := range rules {
+		if len(rt.Conditions) != 1 || rt.Conditions[0].Kind != "when" || rt.Conditions[0].LineNum != 3 {
+			t.Fatalf("want the when condition of firing %d, got %+v", i, rt.Conditions)
+		}
+		if rt.Conditions[0].Operands[0].Left != int64(i) {
+			t.Errorf("want amount %d in the when condition of firing %d, got %+v", i, i, rt.Conditions[0].Operands[0])
+		}
+	}
+	for i, rt := range rules[:2] {
+		if !rt.Conditions[0].Result || rt.Skipped || len(rt.Assignments) != 1 || rt.Assignments[0].Old != int64(i) || rt.Assignments[0].New != int64(i+1) {
+			t.Errorf("unexpected firing %d: %+v", i, rt)
+		}
+	}
+	if last := rules[2]; last.Conditions[0].Result || !last.Skipped || len(last.Assignments) != 0 {
+		t.Errorf("want the false when condition skipped, got %+v", last)
+	}
+}
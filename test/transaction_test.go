@@ -0,0 +1,190 @@
+package test
+
+import (
+	"errors"
+	"gengine/builder"
+	gcontext "gengine/context"
+	"gengine/engine"
+	"strings"
+	"testing"
+)
+
+type Ledger struct {
+	Balance int64
+	Status  string
+	Items   map[string]int64
+	Tags    []string
+}
+
+const transaction_rules = `
+rule "write" salience 30
+begin
+Ledger.Balance = 100
+Ledger.Items["new"] = 1
+Ledger.Items["old"] = 9
+Ledger.Tags[0] = "changed"
+Cap = 7
+x = 1
+end
+
+rule "fail" salience 20
+begin
+Fail()
+end
+
+rule "status" salience 10
+begin
+Ledger.Status = "done"
+end
+`
+
+func newLedger() *Ledger {
+	return &Ledger{Balance: 5, Items: map[string]int64{"old": 1}, Tags: []string{"origin"}}
+}
+
+func buildTransaction(t *testing.T, ledger *Ledger, capacity *int64, rules string) *builder.RuleBuilder {
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Ledger", ledger)
+	dataContext.Add("Cap", capacity)
+	dataContext.Add("Fail", func() { panic("fail") })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(rules)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+	return ruleBuilder
+}
+
+func checkRolledBack(t *testing.T, name string, ledger *Ledger, capacity int64) {
+	if ledger.Balance != 5 || ledger.Status != "" || len(ledger.Items) != 1 || ledger.Items["old"] != 1 || ledger.Tags[0] != "origin" || capacity != 3 {
+		t.Errorf("%s: the writes should be rolled back, got %+v %d", name, ledger, capacity)
+	}
+}
+
+func Test_transaction(t *testing.T) {
+	ledger := newLedger()
+	capacity := int64(3)
+	ruleBuilder := buildTransaction(t, ledger, &capacity, transaction_rules)
+
+	eng := engine.NewGengine()
+	eng.SetTransactional(true)
+	err := eng.Execute(ruleBuilder, true)
+	var re *engine.RuleError
+	if !errors.As(err, &re) || re.RuleName != "fail" {
+		t.Fatalf("want the error of rule fail, got %+v", err)
+	}
+	checkRolledBack(t, "execute", ledger, capacity)
+
+	err = eng.ExecuteSelectedRules(ruleBuilder, []string{"write", "status"})
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if ledger.Balance != 100 || ledger.Status != "done" || ledger.Items["new"] != 1 || ledger.Tags[0] != "changed" || capacity != 7 {
+		t.Errorf("the writes of a successful execution should be kept, got %+v %d", ledger, capacity)
+	}
+}
+
+func Test_transaction_disabled(t *testing.T) {
+	ledger := newLedger()
+	capacity := int64(3)
+	ruleBuilder := buildTransaction(t, ledger, &capacity, transaction_rules)
+
+	err := engine.NewGengine().Execute(ruleBuilder, true)
+	if err == nil {
+		t.Fatalf("want the error of rule fail")
+	}
+	if ledger.Balance != 100 || ledger.Status != "done" || ledger.Items["old"] != 9 || capacity != 7 {
+		t.Errorf("the writes should be kept without the transactional mode, got %+v %d", ledger, capacity)
+	}
+}
+
+const rollback_rules = `
+rule "write" salience 30
+begin
+Ledger.Balance = 100
+Ledger.Items["old"] = 9
+end
+
+rule "check" salience 20
+begin
+if Ledger.Balance > 50 {
+	Cap = 7
+	rollback
+}
+end
+
+rule "status" salience 10
+begin
+Ledger.Status = "done"
+end
+`
+
+func Test_transaction_rollback(t *testing.T) {
+	ledger := newLedger()
+	capacity := int64(3)
+	ruleBuilder := buildTransaction(t, ledger, &capacity, rollback_rules)
+
+	eng := engine.NewGengine()
+	eng.SetTransactional(true)
+	eng.SetReporting(true)
+	err := eng.Execute(ruleBuilder, true)
+	var re *engine.RuleError
+	if !errors.Is(err, engine.ErrRollback) || !errors.As(err, &re) || re.RuleName != "check" || re.Kind != engine.KindRollback || re.LineNum != 12 {
+		t.Fatalf("want the rollback of rule check, got %+v", err)
+	}
+	checkRolledBack(t, "rollback", ledger, capacity)
+	if r := eng.GetReport().Result("status"); r.Status != engine.RuleStopped {
+		t.Errorf("the rules after rollback should not be executed, got %+v", r)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if err == nil || !strings.Contains(err.Error(), "rollback is only supported in the transactional mode") {
+		t.Errorf("want the error of rollback without the transactional mode, got %+v", err)
+	}
+}
+
+func Test_transaction_pool(t *testing.T) {
+	pool, err := engine.NewGenginePool(1, 2, engine.CONCOURRENT_MODEL, transaction_rules, map[string]interface{}{
+		"Fail": func() { panic("fail") },
+	})
+	if err != nil {
+		t.Fatalf("new pool err:%+v", err)
+	}
+	pool.SetTransactional(true)
+
+	ledger := newLedger()
+	capacity := int64(3)
+	err = pool.ExecuteRulesWithMultiInput(map[string]interface{}{"Ledger": ledger, "Cap": &capacity})
+	if err == nil {
+		t.Fatalf("want the error of rule fail")
+	}
+	checkRolledBack(t, "pool", ledger, capacity)
+}
+
+func Test_rollback_names(t *testing.T) {
+	// only the lowercase statement is a keyword
+	var got []int64
+	dataContext := gcontext.NewDataContext()
+	dataContext.Add("Rollback", func(i int64) { got = append(got, i) })
+
+	ruleBuilder := builder.NewRuleBuilder(dataContext)
+	err := ruleBuilder.BuildRuleFromString(`
+rule "names"
+begin
+rollback = 6
+Rollback(rollback)
+end
+`)
+	if err != nil {
+		t.Fatalf("build rules err:%+v", err)
+	}
+
+	err = engine.NewGengine().Execute(ruleBuilder, true)
+	if err != nil {
+		t.Fatalf("execute err:%+v", err)
+	}
+	if len(got) != 1 || got[0] != 6 {
+		t.Errorf("want 6, got %+v", got)
+	}
+}